@@ -7,18 +7,29 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
 
 const (
 	// DateFormat is used for parsing and formatting dates (inc. test data).
+	// See also [FormatDate] and [ParseDate], which support years outside
+	// 0000-9999.
 	DateFormat = `2006-01-02`
 
 	// TimestampFormat is used for parsing and formatting timestamps (inc. test data).
+	// See also [FormatTimestamp] and [ParseTimestamp], which support years
+	// outside 0000-9999.
 	TimestampFormat = "2006-01-02T15:04:05.999999999Z07:00" // RFC 3339 (ns)
 
+	// TimestampSecondsOffsetFormat is a variant of [TimestampFormat], used
+	// for offsets that are not whole minutes (e.g. local mean time), which
+	// RFC 3339 can't represent, e.g. "1900-01-01T00:00:00+00:19:32".
+	TimestampSecondsOffsetFormat = "2006-01-02T15:04:05.999999999Z07:00:00"
+
 	oneDay = 24 * time.Hour
 )
 
@@ -36,6 +47,11 @@ type (
 	// inclusive).
 	// Default values are treated as not set / ignored.
 	DateToTimestamp func(startDate, endDate string) (startTime, endTime time.Time)
+
+	// Widen is intended to extend a timestamp range, to include any
+	// overlapping days, see [WidenRange].
+	// Default values are treated as not set / ignored.
+	Widen func(startTime, endTime time.Time) (wideStartTime, wideEndTime time.Time)
 )
 
 // MatchesTimestamp demonstrates matching a timestamp against a range.
@@ -56,12 +72,12 @@ func MatchesTimestamp(startTime, endTime, value time.Time) bool {
 // Unlike MatchesTimestamp, the endTime is inclusive, because dates are
 // discrete (though a half-open range would also work).
 func MatchesDate(startDate, endDate, value string) bool {
-	val, err := time.ParseInLocation(DateFormat, value, time.UTC)
+	val, err := ParseDate(value)
 	if err != nil {
 		panic(err)
 	}
 	if startDate != `` {
-		startDate, err := time.ParseInLocation(DateFormat, startDate, time.UTC)
+		startDate, err := ParseDate(startDate)
 		if err != nil {
 			panic(err)
 		}
@@ -70,7 +86,7 @@ func MatchesDate(startDate, endDate, value string) bool {
 		}
 	}
 	if endDate != `` {
-		endDate, err := time.ParseInLocation(DateFormat, endDate, time.UTC)
+		endDate, err := ParseDate(endDate)
 		if err != nil {
 			panic(err)
 		}
@@ -108,6 +124,8 @@ func WidenRange(start, end time.Time) (time.Time, time.Time) {
 	return WidenStartTime(start), WidenEndTime(end)
 }
 
+var _ Widen = WidenRange // compile-time type assertion (unnecessary)
+
 // N.B. All the examples treat dates as normalised to 00:00:00 UTC.
 
 func ExampleTimestampToDate(startTime, endTime time.Time) (startDate, endDate string) {
@@ -127,7 +145,7 @@ func ExampleTimestampToDate(startTime, endTime time.Time) (startDate, endDate st
 		}
 
 		// 3. The actual truncation is done here, in this implementation
-		startDate = startTime.Format(DateFormat)
+		startDate = FormatDate(startTime)
 	}
 
 	if endTime != (time.Time{}) {
@@ -140,7 +158,7 @@ func ExampleTimestampToDate(startTime, endTime time.Time) (startDate, endDate st
 		endTime = endTime.Add(-oneDay)
 
 		// 3. The actual truncation is done here, in this implementation
-		endDate = endTime.Format(DateFormat)
+		endDate = FormatDate(endTime)
 	}
 
 	return
@@ -154,7 +172,7 @@ func ExampleDateToTimestamp(startDate, endDate string) (startTime, endTime time.
 	if startDate != `` {
 		// 1. Parse the date in UTC, because that is what they are documented
 		// to represent (time will be start of day)
-		startTime, err = time.ParseInLocation(DateFormat, startDate, time.UTC)
+		startTime, err = ParseDate(startDate)
 		if err != nil {
 			panic(err)
 		}
@@ -164,7 +182,7 @@ func ExampleDateToTimestamp(startDate, endDate string) (startTime, endTime time.
 
 	if endDate != `` {
 		// 1. Parse in UTC, to get our initial timestamp
-		endTime, err = time.ParseInLocation(DateFormat, endDate, time.UTC)
+		endTime, err = ParseDate(endDate)
 		if err != nil {
 			panic(err)
 		}
@@ -186,18 +204,28 @@ func AssertDate(t *testing.T, s string) {
 	}
 }
 
-// ValidateDate ensures that s is a valid date.
+// ValidateDate ensures that s is a valid date, in the canonical format, as
+// produced by [FormatDate].
 func ValidateDate(s string) error {
-	d, err := time.ParseInLocation(DateFormat, s, time.UTC)
+	d, err := ParseDate(s)
 	if err != nil {
 		return err
 	}
-	if d.Format(DateFormat) != s {
+	if FormatDate(d) != s {
 		return errors.New(`date format mismatch`)
 	}
 	return nil
 }
 
+// rangeVariants returns ranges, including the variants where one bound is
+// unset, as used by [RangeTestCases].
+func rangeVariants(ranges [][2]string) (result [][2]string) {
+	for _, r := range ranges {
+		result = append(result, r, [2]string{``, r[1]}, [2]string{r[0], ``})
+	}
+	return
+}
+
 // RangeTestCases is a utility for iterating on all combinations of ranges and
 // values, including ranges where one side is set to the zero value.
 func RangeTestCases(ranges [][2]string, values []string, f func(r [2]string, v string) bool) {
@@ -225,15 +253,27 @@ func RangeTestCases(ranges [][2]string, values []string, f func(r [2]string, v s
 }
 
 // TestTimestampToDate may be used to test a [TimestampToDate] implementation.
-// The ranges are timestamps, and the values are dates.
+// The ranges are timestamps, and the values are dates. Each case is a
+// subtest, run sequentially.
 func TestTimestampToDate(t *testing.T, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert TimestampToDate) {
-	if err := testTimestampToDate(nil, t, ranges, values, matches, convert); err != nil {
+	if err := testTimestampToDate(nil, t, 1, ranges, values, matches, convert); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestTimestampToDateParallel is a variant of [TestTimestampToDate] that runs
+// up to parallelism cases concurrently, as parallel subtests (also limited
+// by the -parallel flag of go test), so convert must be safe for concurrent
+// use. As with [TestTimestampToDate], it returns once every case has run.
+func TestTimestampToDateParallel(t *testing.T, parallelism int, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert TimestampToDate) {
+	if err := testTimestampToDate(nil, t, parallelism, ranges, values, matches, convert); err != nil {
 		t.Fatal(err)
 	}
 }
 
 // TestTimestampToDateExternal is a variant of [TestTimestampToDate] that does
-// not require a testing.T instance.
+// not require a testing.T instance. Cases are run sequentially, see
+// [VerifyTimestampToDate] for parallel execution.
 func TestTimestampToDateExternal(
 	ctx context.Context,
 	ranges [][2]string,
@@ -241,12 +281,65 @@ func TestTimestampToDateExternal(
 	matches map[[3]string]struct{},
 	convert TimestampToDate,
 ) error {
-	return testTimestampToDate(ctx, nil, ranges, values, matches, convert)
+	return testTimestampToDate(ctx, nil, 1, ranges, values, matches, convert)
+}
+
+func testTimestampToDate(ctx context.Context, t *testing.T, parallelism int, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert TimestampToDate) error {
+	return runCases(ctx, t, parallelism, ranges, values, func(r [2]string, value string) CaseResult {
+		return checkTimestampToDateCase(r, value, matches, convert)
+	})
+}
+
+// TestDateToTimestamp may be used to test a [DateToTimestamp] implementation.
+// The ranges are dates, and the values are timestamps. As with
+// [TestTimestampToDate], each case is a subtest, run sequentially.
+func TestDateToTimestamp(t *testing.T, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert DateToTimestamp) {
+	if err := testDateToTimestamp(nil, t, 1, ranges, values, matches, convert); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDateToTimestampParallel is the [DateToTimestamp] equivalent of
+// [TestTimestampToDateParallel].
+func TestDateToTimestampParallel(t *testing.T, parallelism int, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert DateToTimestamp) {
+	if err := testDateToTimestamp(nil, t, parallelism, ranges, values, matches, convert); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDateToTimestampExternal is a variant of [TestDateToTimestamp] that does
+// not require a testing.T instance. Cases are run sequentially, see
+// [VerifyDateToTimestamp] for parallel execution.
+func TestDateToTimestampExternal(
+	ctx context.Context,
+	ranges [][2]string,
+	values []string,
+	matches map[[3]string]struct{},
+	convert DateToTimestamp,
+) error {
+	return testDateToTimestamp(ctx, nil, 1, ranges, values, matches, convert)
 }
 
-func testTimestampToDate(ctx context.Context, t *testing.T, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert TimestampToDate) error {
+func testDateToTimestamp(ctx context.Context, t *testing.T, parallelism int, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert DateToTimestamp) error {
+	return runCases(ctx, t, parallelism, ranges, values, func(r [2]string, value string) CaseResult {
+		return checkDateToTimestampCase(r, value, matches, convert)
+	})
+}
+
+// runCases runs check for each of [RangeTestCases], as subtests, if t is
+// non-nil, otherwise collecting every failure, returning a
+// [VerificationError], and/or the context error. If parallelism is greater
+// than one, the subtests are parallel, grouped so they finish before it
+// returns, but otherwise the cases are run sequentially.
+func runCases(ctx context.Context, t *testing.T, parallelism int, ranges [][2]string, values []string, check func(r [2]string, value string) CaseResult) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var mu sync.Mutex
 	result := make(map[[3]string]struct{})
 	setMatches := func(r [2]string, v string, matches bool) {
+		mu.Lock()
+		defer mu.Unlock()
 		k := [3]string{r[0], r[1], v}
 		if matches {
 			result[k] = struct{}{}
@@ -262,7 +355,7 @@ func testTimestampToDate(ctx context.Context, t *testing.T, ranges [][2]string,
 		logf = func(s string, a ...any) { fmt.Printf(s+"\n", a...) }
 	}
 
-	cleanup := func() {
+	logMatches := func() {
 		logf(`actual matches: %s`,
 			strings.NewReplacer(
 				"[3]string{", "{",
@@ -270,166 +363,172 @@ func testTimestampToDate(ctx context.Context, t *testing.T, ranges [][2]string,
 				`struct{}{}`, `{}`,
 			).Replace(fmt.Sprintf("%#v", result)))
 	}
-	if t != nil {
-		t.Cleanup(cleanup)
-	} else {
-		defer cleanup()
-	}
-
-	RangeTestCases(ranges, values, func(r [2]string, value string) bool {
-		name := r[0] + `-` + r[1] + `-` + value
-		f := func(t *testing.T) {
-			logf := logf
-			var fatalf func(string, ...any)
-			if t != nil {
-				logf = t.Logf
-				fatalf = t.Fatalf
-			} else {
-				l := logf
-				logf = func(f string, args ...any) { l(`[%s] `+f, append([]any{name}, args...)...) }
-				fatalf = func(f string, args ...any) {
-					logf(f, args...)
-					panic(fmt.Sprintf(f, args...))
-				}
-			}
-
-			var startTime, endTime time.Time
-			if r[0] != `` {
-				var err error
-				startTime, err = time.ParseInLocation(TimestampFormat, r[0], time.UTC)
-				if err != nil {
-					t.Fatal(err)
-				}
-			}
-			if r[1] != `` {
-				var err error
-				endTime, err = time.ParseInLocation(TimestampFormat, r[1], time.UTC)
-				if err != nil {
-					t.Fatal(err)
-				}
-			}
 
-			if err := ValidateDate(value); err != nil {
-				fatalf(`value error: %v`, err)
-			}
-
-			startDate, endDate := convert(startTime, endTime)
-			if r[0] != `` {
-				if err := ValidateDate(startDate); err != nil {
-					fatalf(`startDate error: %v`, err)
-				}
+	if t == nil {
+		report := verifyCases(ctx, 1, ``, ranges, values, func(_ int, r [2]string, value string) CaseResult {
+			return check(r, value)
+		}, func(v CaseResult) {
+			setMatches(v.Range, v.Value, v.Actual)
+			if v.Err != nil {
+				logf(`[%s] %v`, v.Name, v.Err)
 			}
-			if r[1] != `` {
-				if err := ValidateDate(endDate); err != nil {
-					fatalf(`endDate error: %v`, err)
-				}
-			}
-
-			actual := MatchesDate(startDate, endDate, value)
-
-			setMatches(r, value, actual)
+		})
+		logMatches()
+		logf(`summary: %d passed, %d failed, of %d cases`, report.Passed(), report.Failed(), len(report.Cases))
+		return report.Failure()
+	}
 
-			if _, expected := matches[[3]string{r[0], r[1], value}]; actual != expected {
-				t.Fatalf(`expected %t, got %t: [%s, %s] matching %s`, expected, actual, startDate, endDate, value)
-			}
-		}
-		if t != nil {
-			t.Run(name, f)
-		} else {
-			f(nil)
+	t.Cleanup(logMatches)
+	run := func(t *testing.T, r [2]string, value string) {
+		v := check(r, value)
+		setMatches(r, value, v.Actual)
+		if v.Err != nil {
+			t.Fatal(v.Err)
 		}
-
-		return ctx == nil || ctx.Err() == nil
-	})
-
-	if ctx == nil {
+	}
+	if parallelism <= 1 {
+		RangeTestCases(ranges, values, func(r [2]string, value string) bool {
+			t.Run(r[0]+`-`+r[1]+`-`+value, func(t *testing.T) { run(t, r, value) })
+			return ctx.Err() == nil
+		})
 		return nil
 	}
-
-	return context.Cause(ctx)
+	// N.B. parallel subtests only run once their parent's function returns
+	sem := make(chan struct{}, parallelism)
+	t.Run(`parallel`, func(t *testing.T) {
+		RangeTestCases(ranges, values, func(r [2]string, value string) bool {
+			t.Run(r[0]+`-`+r[1]+`-`+value, func(t *testing.T) {
+				t.Parallel()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				if ctx.Err() != nil {
+					t.Skip(ctx.Err())
+				}
+				run(t, r, value)
+			})
+			return true
+		})
+	})
+	return nil
 }
 
-// TestDateToTimestamp may be used to test a [DateToTimestamp] implementation.
-// The ranges are dates, and the values are timestamps.
-func TestDateToTimestamp(t *testing.T, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert DateToTimestamp) {
-	result := make(map[[3]string]struct{})
-	setMatches := func(r [2]string, v string, matches bool) {
-		k := [3]string{r[0], r[1], v}
-		if matches {
-			result[k] = struct{}{}
-		} else {
-			delete(result, k)
+func FuzzTimestampToDate(f *testing.F, ranges [][2]string, values []string, convert TimestampToDate) {
+	addTimestampToDateSeeds(f, ranges, values)
+	f.Fuzz(func(t *testing.T, startTimeEpoch int64, startTimeOffset int, endTimeEpoch int64, endTimeOffset int, valueEpoch int64, ignoreStart, ignoreEnd bool) {
+		c := timestampToDateFuzzInput(t, startTimeEpoch, startTimeOffset, endTimeEpoch, endTimeOffset, valueEpoch, ignoreStart, ignoreEnd)
+		if err := CheckFuzzTimestampToDate(c, convert); err != nil {
+			// simplify the failure, to aid in diagnosing it
+			if result := ShrinkTimestampToDate(c, convert); result != nil {
+				t.Log(result)
+			}
+			t.Fatal(err)
 		}
-	}
-
-	t.Cleanup(func() {
-		t.Logf(`actual matches: %s`,
-			strings.NewReplacer(
-				"[3]string{", "{",
-				`struct {}{}`, `{}`,
-				`struct{}{}`, `{}`,
-			).Replace(fmt.Sprintf("%#v", result)))
 	})
+}
 
-	RangeTestCases(ranges, values, func(r [2]string, valStr string) bool {
-		t.Run(r[0]+`-`+r[1]+`-`+valStr, func(t *testing.T) {
-			value, err := time.ParseInLocation(TimestampFormat, valStr, time.UTC)
-			if err != nil {
-				t.Fatal(err)
-			}
+// CheckFuzzTimestampToDate performs the checks of [FuzzTimestampToDate],
+// for a single case, returning an error describing any failure.
+func CheckFuzzTimestampToDate(c TimestampToDateCase, convert TimestampToDate) error {
+	startTime, endTime, value := c.StartTime, c.EndTime, c.Value
+	ignoreStart, ignoreEnd := startTime == (time.Time{}), endTime == (time.Time{})
 
-			if r[0] != `` {
-				AssertDate(t, r[0])
-			}
-			if r[1] != `` {
-				AssertDate(t, r[1])
-			}
+	// N.B. differing from the baseline is reported, but doesn't stop the checks
+	var errs []error
+	fail := func(format string, a ...any) error {
+		return errors.Join(append(errs, fmt.Errorf(format, a...))...)
+	}
 
-			startTime, endTime := convert(r[0], r[1])
-			if (r[0] == ``) != (startTime == (time.Time{})) {
-				t.Fatal(`start time zero value mismatch for input:`, r[0])
-			}
-			if (r[1] == ``) != (endTime == (time.Time{})) {
-				t.Fatal(`end time time zero value mismatch for input:`, r[1])
-			}
+	startDate, endDate := convert(startTime, endTime)
+	if a, b := ExampleTimestampToDate(startTime, endTime); a != startDate || b != endDate {
+		errs = append(errs, fmt.Errorf("differed from baseline for [%s, %s): expected [%s, %s], got [%s, %s]", FormatTimestamp(startTime), FormatTimestamp(endTime), a, b, startDate, endDate))
+	}
 
-			actual := MatchesTimestamp(startTime, endTime, value)
+	if ignoreStart != (startDate == ``) {
+		return fail("ignoreStart=%t, startDate=%s", ignoreStart, startDate)
+	}
+	if ignoreEnd != (endDate == ``) {
+		return fail("ignoreEnd=%t, endDate=%s", ignoreEnd, endDate)
+	}
 
-			setMatches(r, valStr, actual)
+	var startDateParsed, endDateParsed time.Time
+	var err error
+	if !ignoreStart {
+		startDateParsed, err = ParseDate(startDate)
+		if err != nil || FormatDate(startDateParsed) != startDate {
+			return fail("invalid startDate %q: %v", startDate, err)
+		}
+	}
+	if !ignoreEnd {
+		endDateParsed, err = ParseDate(endDate)
+		if err != nil || FormatDate(endDateParsed) != endDate {
+			return fail("invalid endDate %q: %v", endDate, err)
+		}
+	}
+	if !ignoreStart && !ignoreEnd && startDateParsed.After(endDateParsed) {
+		return fail("startDate is after endDate: startDate=%s (%s), endDate=%s (%s)",
+			startDate, FormatTimestamp(startTime),
+			endDate, FormatTimestamp(endTime))
+	}
 
-			if _, expected := matches[[3]string{r[0], r[1], valStr}]; actual != expected {
-				t.Fatalf(
-					`expected %t, got %t: [%s, %s] matching %s`,
-					expected,
-					actual,
-					startTime.Format(TimestampFormat),
-					endTime.Format(TimestampFormat),
-					value.Format(TimestampFormat),
-				)
-			}
-		})
-		return true
-	})
+	// determine lower, and approximate inclusive upper bound for what would normalise to value
+	valueLower, err := ParseDate(value)
+	if err != nil {
+		return fail("invalid value %q: %v", value, err)
+	}
+	// N.B. only once the dates are known to be valid, as it panics otherwise
+	matches := MatchesDate(startDate, endDate, value)
+	valueUpper := valueLower.Add(24*time.Hour - time.Nanosecond) // not actual upper, but upper representable here
+
+	// the trivial cases for matching the original range
+	valueLowerMatches := (ignoreStart || !startTime.After(valueLower)) &&
+		(ignoreEnd || endTime.After(valueLower))
+	valueUpperMatches := (ignoreStart || !startTime.After(valueUpper)) &&
+		(ignoreEnd || endTime.After(valueUpper))
+
+	// Both the upper and lower bound must match to be considered a match, otherwise the date isn't wholly
+	// contained in the range. If we didn't handle matches this way, it may break "contiguous ranges".
+	if matches != (valueUpperMatches && valueLowerMatches) {
+		return fail(
+			"expected %t, got (%t && %t):\ntimestamp range [%s, %s) -> date range [%s, %s]\n\tmatching\ndate value %s -> approx. timestamp value(s) between %s and %s (inclusive)",
+			matches,
+			valueLowerMatches,
+			valueUpperMatches,
+			FormatTimestamp(startTime),
+			FormatTimestamp(endTime),
+			startDate,
+			endDate,
+			value,
+			FormatTimestamp(valueLower),
+			FormatTimestamp(valueUpper),
+		)
+	}
+	return errors.Join(errs...)
 }
 
-func FuzzTimestampToDate(f *testing.F, ranges [][2]string, values []string, convert TimestampToDate) {
+// addTimestampToDateSeeds adds the seed corpus of [FuzzTimestampToDate],
+// i.e. each of [RangeTestCases], with a variety of offsets, and the
+// transitions of the system tzdata, see [ZoneTransitions].
+func addTimestampToDateSeeds(f *testing.F, ranges [][2]string, values []string) {
 	offsetSecondsEastOfUTCValues := [...]int{math.MaxInt, -43200, -36000, -32400, -25200, -18000, -14400, -7200, 0, 3600, 7200, 14400, 18000, 25200, 32400, 43200}
+	// less common offsets, e.g. +05:45, +08:45, -03:30, and local mean time
+	// (which has seconds), applied to both bounds
+	irregularOffsetSecondsEastOfUTCValues := [...]int{20700, 31500, -12600, 50400, 1172, -17762, 36892, -1}
 	RangeTestCases(ranges, values, func(r [2]string, v string) bool {
 		var startTime, endTime time.Time
 		var err error
 		if r[0] != `` {
-			startTime, err = time.ParseInLocation(TimestampFormat, r[0], time.UTC)
+			startTime, err = ParseTimestamp(r[0])
 			if err != nil {
 				f.Fatal(err)
 			}
 		}
 		if r[1] != `` {
-			endTime, err = time.ParseInLocation(TimestampFormat, r[1], time.UTC)
+			endTime, err = ParseTimestamp(r[1])
 			if err != nil {
 				f.Fatal(err)
 			}
 		}
-		value, err := time.ParseInLocation(DateFormat, v, time.UTC)
+		value, err := ParseDate(v)
 		if err != nil {
 			f.Fatal(err)
 		}
@@ -443,102 +542,229 @@ func FuzzTimestampToDate(f *testing.F, ranges [][2]string, values []string, conv
 				}
 				f.Add(
 					startTime.UnixNano(),
-					startOffset,
+					encodeFuzzOffset(startOffset),
 					endTime.UnixNano(),
-					endOffset,
+					encodeFuzzOffset(endOffset),
 					value.UnixNano(),
 					startTime == (time.Time{}),
 					endTime == (time.Time{}),
 				)
 			}
 		}
+		for _, offset := range irregularOffsetSecondsEastOfUTCValues {
+			f.Add(
+				startTime.UnixNano(),
+				encodeFuzzOffset(offset),
+				endTime.UnixNano(),
+				encodeFuzzOffset(offset),
+				value.UnixNano(),
+				startTime == (time.Time{}),
+				endTime == (time.Time{}),
+			)
+		}
 		return true
 	})
-	f.Fuzz(func(t *testing.T, startTimeEpoch int64, startTimeOffset int, endTimeEpoch int64, endTimeOffset int, valueEpoch int64, ignoreStart, ignoreEnd bool) {
-		if ignoreStart && ignoreEnd {
-			t.Skip("skipping invalid range where both start and end are ignored")
-		} else if !ignoreStart && !ignoreEnd && (startTimeEpoch >= endTimeEpoch || time.Duration(endTimeEpoch-startTimeEpoch) < 24*time.Hour) {
-			t.Skipf("skipping invalid range where endTime (%s) is not at least 1 full day after startTime (%s)",
-				time.Unix(0, startTimeEpoch).UTC().Format(TimestampFormat),
-				time.Unix(0, endTimeEpoch).UTC().Format(TimestampFormat))
-		}
+	addZoneTransitionSeeds(f)
+}
 
-		// normalise to the nearest minute (seconds not representable in encoded format
-		startTimeOffset = startTimeOffset / 60 * 60
-		endTimeOffset = endTimeOffset / 60 * 60
+// ErrFuzzInputSkipped is wrapped by the errors of
+// [DecodeTimestampToDateFuzzArgs], for inputs that the fuzz test would skip.
+var ErrFuzzInputSkipped = errors.New(`fuzz input skipped`)
+
+// encodeFuzzOffset encodes an offset (seconds east of UTC), within a day, as
+// an argument of the fuzz tests, see decodeFuzzOffset.
+func encodeFuzzOffset(offset int) int {
+	switch {
+	case offset%60 == 0:
+		return offset
+	case offset < 0:
+		return offset - 24*60*60
+	default:
+		return offset + 24*60*60
+	}
+}
 
-		var startTime, endTime time.Time
-		if !ignoreStart {
-			startTime = time.Unix(0, startTimeEpoch).In(time.FixedZone("", startTimeOffset))
+// decodeFuzzOffset decodes an offset argument of the fuzz tests, normalising
+// it to within a day, as larger offsets are not representable. For
+// compatibility with existing corpora, offsets within a day are truncated to
+// whole minutes, as they always have been, while larger offsets keep their
+// seconds, e.g. +00:00:50 is encoded as 86450, see encodeFuzzOffset.
+func decodeFuzzOffset(v int) int {
+	const day = 24 * 60 * 60
+	if v > -day && v < day {
+		return v / 60 * 60
+	}
+	return v % day
+}
+
+// DecodeTimestampToDateFuzzArgs decodes the arguments of
+// [FuzzTimestampToDate], e.g. as read from a corpus file, in the same way as
+// the fuzz test. The arguments must be of the same types as those of the
+// fuzz function.
+func DecodeTimestampToDateFuzzArgs(args ...any) (TimestampToDateCase, error) {
+	var (
+		startTimeEpoch, endTimeEpoch, valueEpoch int64
+		startTimeOffset, endTimeOffset           int
+		ignoreStart, ignoreEnd                   bool
+	)
+	targets := [...]any{&startTimeEpoch, &startTimeOffset, &endTimeEpoch, &endTimeOffset, &valueEpoch, &ignoreStart, &ignoreEnd}
+	if len(args) != len(targets) {
+		return TimestampToDateCase{}, fmt.Errorf(`expected %d fuzz arguments, got %d`, len(targets), len(args))
+	}
+	for i, arg := range args {
+		var ok bool
+		switch target := targets[i].(type) {
+		case *int64:
+			*target, ok = arg.(int64)
+		case *int:
+			*target, ok = arg.(int)
+		case *bool:
+			*target, ok = arg.(bool)
 		}
-		if !ignoreEnd {
-			endTime = time.Unix(0, endTimeEpoch).In(time.FixedZone("", endTimeOffset))
+		if !ok {
+			return TimestampToDateCase{}, fmt.Errorf(`fuzz argument %d: expected %T, got %T`, i, reflect.ValueOf(targets[i]).Elem().Interface(), arg)
 		}
+	}
+	return decodeTimestampToDateFuzzInput(startTimeEpoch, startTimeOffset, endTimeEpoch, endTimeOffset, valueEpoch, ignoreStart, ignoreEnd)
+}
 
-		value := time.Unix(0, valueEpoch).In(time.UTC).Format(DateFormat)
+// timestampToDateFuzzInput decodes the arguments of [FuzzTimestampToDate],
+// skipping invalid ranges.
+func timestampToDateFuzzInput(t *testing.T, startTimeEpoch int64, startTimeOffset int, endTimeEpoch int64, endTimeOffset int, valueEpoch int64, ignoreStart, ignoreEnd bool) TimestampToDateCase {
+	t.Helper()
+	c, err := decodeTimestampToDateFuzzInput(startTimeEpoch, startTimeOffset, endTimeEpoch, endTimeOffset, valueEpoch, ignoreStart, ignoreEnd)
+	if err != nil {
+		t.Skip(err)
+	}
+	return c
+}
 
-		startDate, endDate := convert(startTime, endTime)
-		if a, b := ExampleTimestampToDate(startTime, endTime); a != startDate || b != endDate {
-			t.Errorf("differed from baseline for [%s, %s): expected [%s, %s], got [%s, %s]", startTime.Format(TimestampFormat), endTime.Format(TimestampFormat), a, b, startDate, endDate)
-		}
+func decodeTimestampToDateFuzzInput(startTimeEpoch int64, startTimeOffset int, endTimeEpoch int64, endTimeOffset int, valueEpoch int64, ignoreStart, ignoreEnd bool) (c TimestampToDateCase, err error) {
+	if ignoreStart && ignoreEnd {
+		return c, fmt.Errorf(`%w: invalid range where both start and end are ignored`, ErrFuzzInputSkipped)
+	} else if !ignoreStart && !ignoreEnd && (startTimeEpoch >= endTimeEpoch || time.Unix(0, endTimeEpoch).Sub(time.Unix(0, startTimeEpoch)) < oneDay) {
+		return c, fmt.Errorf(`%w: invalid range where endTime (%s) is not at least 1 full day after startTime (%s)`,
+			ErrFuzzInputSkipped,
+			FormatTimestamp(time.Unix(0, endTimeEpoch).UTC()),
+			FormatTimestamp(time.Unix(0, startTimeEpoch).UTC()))
+	}
+
+	startTimeOffset = decodeFuzzOffset(startTimeOffset)
+	endTimeOffset = decodeFuzzOffset(endTimeOffset)
+
+	if !ignoreStart {
+		c.StartTime = time.Unix(0, startTimeEpoch).In(time.FixedZone("", startTimeOffset))
+	}
+	if !ignoreEnd {
+		c.EndTime = time.Unix(0, endTimeEpoch).In(time.FixedZone("", endTimeOffset))
+	}
+
+	c.Value = FormatDate(time.Unix(0, valueEpoch).In(time.UTC))
+	return
+}
 
-		if ignoreStart != (startDate == ``) {
-			t.Fatalf("ignoreStart=%t, startDate=%s", ignoreStart, startDate)
+// FuzzDateToTimestamp may be used to fuzz test a [DateToTimestamp]
+// implementation, against [ExampleDateToTimestamp]. The ranges are dates, and
+// the values are timestamps, both of which are used as seeds.
+func FuzzDateToTimestamp(f *testing.F, ranges [][2]string, values []string, convert DateToTimestamp) {
+	RangeTestCases(ranges, values, func(r [2]string, v string) bool {
+		var startDate, endDate int32
+		var err error
+		if r[0] != `` {
+			startDate, err = ParseEpochDate(r[0])
+			if err != nil {
+				f.Fatal(err)
+			}
 		}
-		if ignoreEnd != (endDate == ``) {
-			t.Fatalf("ignoreEnd=%t, endDate=%s", ignoreEnd, endDate)
+		if r[1] != `` {
+			endDate, err = ParseEpochDate(r[1])
+			if err != nil {
+				f.Fatal(err)
+			}
+		}
+		value, err := ParseTimestamp(v)
+		if err != nil {
+			f.Fatal(err)
+		}
+		_, offset := value.Zone()
+		f.Add(startDate, endDate, value.UnixNano(), encodeFuzzOffset(offset), r[0] == ``, r[1] == ``)
+		return true
+	})
+	// leap days and year boundaries, with values either side of the bounds
+	for _, r := range [...][2]string{
+		{"2024-02-29", "2024-02-29"},
+		{"2023-02-28", "2023-03-01"},
+		{"2023-12-31", "2024-01-01"},
+		{"2000-02-29", "2100-02-28"},
+		{"1969-12-31", "1970-01-01"},
+	} {
+		startDate, _ := ParseEpochDate(r[0])
+		endDate, _ := ParseEpochDate(r[1])
+		startTime, endTime := ExampleDateToTimestamp(r[0], r[1])
+		for _, value := range [...]time.Time{startTime, startTime.Add(-time.Nanosecond), endTime, endTime.Add(-time.Nanosecond)} {
+			for _, offset := range [...]int{0, -36000, 36000} {
+				f.Add(startDate, endDate, value.UnixNano(), offset, false, false)
+			}
+		}
+	}
+	f.Fuzz(func(t *testing.T, startDateEpoch, endDateEpoch int32, valueEpoch int64, valueOffset int, ignoreStart, ignoreEnd bool) {
+		if ignoreStart && ignoreEnd {
+			t.Skip("skipping invalid range where both start and end are ignored")
+		} else if !ignoreStart && !ignoreEnd && startDateEpoch > endDateEpoch {
+			t.Skipf("skipping invalid range where startDate (%s) is after endDate (%s)",
+				EpochDate(startDateEpoch), EpochDate(endDateEpoch))
 		}
 
-		matches := MatchesDate(startDate, endDate, value)
+		valueOffset = decodeFuzzOffset(valueOffset)
 
-		var startDateParsed, endDateParsed time.Time
-		var err error
+		var startDate, endDate string
 		if !ignoreStart {
-			startDateParsed, err = time.ParseInLocation(DateFormat, startDate, time.UTC)
-			if err != nil || startDateParsed.Format(DateFormat) != startDate {
-				t.Fatal(startDateParsed, err)
-			}
+			startDate = EpochDate(startDateEpoch)
 		}
 		if !ignoreEnd {
-			endDateParsed, err = time.ParseInLocation(DateFormat, endDate, time.UTC)
-			if err != nil || endDateParsed.Format(DateFormat) != endDate {
-				t.Fatal(endDateParsed, err)
-			}
+			endDate = EpochDate(endDateEpoch)
 		}
-		if !ignoreStart && !ignoreEnd && startDateParsed.After(endDateParsed) {
-			t.Fatalf("startDate is after endDate: startDate=%s (%s), endDate=%s (%s)",
-				startDate, startTime.Format(TimestampFormat),
-				endDate, endTime.Format(TimestampFormat))
+
+		value := time.Unix(0, valueEpoch).In(time.FixedZone("", valueOffset))
+
+		startTime, endTime := convert(startDate, endDate)
+		if a, b := ExampleDateToTimestamp(startDate, endDate); !a.Equal(startTime) || !b.Equal(endTime) {
+			t.Errorf("differed from baseline for [%s, %s]: expected [%s, %s), got [%s, %s)", startDate, endDate, FormatTimestamp(a), FormatTimestamp(b), FormatTimestamp(startTime), FormatTimestamp(endTime))
 		}
 
-		// determine lower, and approximate inclusive upper bound for what would normalise to value
-		valueLower, err := time.ParseInLocation(DateFormat, value, time.UTC)
-		if err != nil {
-			t.Fatal(err)
+		if ignoreStart != (startTime == (time.Time{})) {
+			t.Fatalf("ignoreStart=%t, startTime=%s", ignoreStart, FormatTimestamp(startTime))
+		}
+		if ignoreEnd != (endTime == (time.Time{})) {
+			t.Fatalf("ignoreEnd=%t, endTime=%s", ignoreEnd, FormatTimestamp(endTime))
 		}
-		valueUpper := valueLower.Add(24*time.Hour - time.Nanosecond) // not actual upper, but upper representable here
 
-		// the trivial cases for matching the original range
-		valueLowerMatches := (ignoreStart || !startTime.After(valueLower)) &&
-			(ignoreEnd || endTime.After(valueLower))
-		valueUpperMatches := (ignoreStart || !startTime.After(valueUpper)) &&
-			(ignoreEnd || endTime.After(valueUpper))
+		// dates are always UTC midnight (start of day), regardless of the local zone
+		if !ignoreStart && !WidenStartTime(startTime).Equal(startTime) {
+			t.Fatalf("startTime is not the start of a UTC day: startDate=%s, startTime=%s", startDate, FormatTimestamp(startTime))
+		}
+		if !ignoreEnd && !WidenStartTime(endTime).Equal(endTime) {
+			t.Fatalf("endTime is not the start of a UTC day: endDate=%s, endTime=%s", endDate, FormatTimestamp(endTime))
+		}
+		if !ignoreStart && !ignoreEnd && !startTime.Before(endTime) {
+			t.Fatalf("startTime is not before endTime: startDate=%s (%s), endDate=%s (%s)",
+				startDate, FormatTimestamp(startTime),
+				endDate, FormatTimestamp(endTime))
+		}
 
-		// Both the upper and lower bound must match to be considered a match, otherwise the date isn't wholly
-		// contained in the range. If we didn't handle matches this way, it may break "contiguous ranges".
-		if matches != (valueUpperMatches && valueLowerMatches) {
+		// the timestamp should match iff its UTC date is within the date range
+		valueDate := FormatDate(value.UTC())
+		if matches, expected := MatchesTimestamp(startTime, endTime, value), MatchesDate(startDate, endDate, valueDate); matches != expected {
 			t.Fatalf(
-				"expected %t, got (%t && %t):\ntimestamp range [%s, %s) -> date range [%s, %s]\n\tmatching\ndate value %s -> approx. timestamp value(s) between %s and %s (inclusive)",
+				"expected %t, got %t:\ndate range [%s, %s] -> timestamp range [%s, %s)\n\tmatching\ntimestamp value %s (UTC date %s)",
+				expected,
 				matches,
-				valueLowerMatches,
-				valueUpperMatches,
-				startTime.Format(TimestampFormat),
-				endTime.Format(TimestampFormat),
 				startDate,
 				endDate,
-				value,
-				valueLower.Format(TimestampFormat),
-				valueUpper.Format(TimestampFormat),
+				FormatTimestamp(startTime),
+				FormatTimestamp(endTime),
+				FormatTimestamp(value),
+				valueDate,
 			)
 		}
 	})
@@ -634,733 +860,3 @@ var TimestampRangeValues = [][2]string{
 	{"2023-03-12T01:59:59-07:00", "2023-03-12T03:00:00-07:00"}, // around DST start
 	{"2023-11-05T00:59:59-07:00", "2023-11-05T02:00:00-08:00"}, // around DST end
 }
-
-// ExampleMatches are all tuples like (range_i, range_j, value_k), which return
-// true, from their corresponding/appropriate comparison function, where
-// value_k differs in format (same-format is uninteresting).
-var ExampleMatches = map[[3]string]struct{}{
-	// for TimestampToDate
-
-	{"", "2017-01-01T00:00:00Z", "2016-12-31"}:                               {},
-	{"", "2022-01-31T23:59:59Z", "2016-12-31"}:                               {},
-	{"", "2022-01-31T23:59:59Z", "2022-01-01"}:                               {},
-	{"", "2023-02-28T23:59:59Z", "2016-12-31"}:                               {},
-	{"", "2023-02-28T23:59:59Z", "2022-01-01"}:                               {},
-	{"", "2023-03-12T03:00:00-07:00", "2016-12-31"}:                          {},
-	{"", "2023-03-12T03:00:00-07:00", "2022-01-01"}:                          {},
-	{"", "2023-03-12T03:00:00-07:00", "2023-02-28"}:                          {},
-	{"", "2023-07-31T23:59:59+09:00", "2016-12-31"}:                          {},
-	{"", "2023-07-31T23:59:59+09:00", "2022-01-01"}:                          {},
-	{"", "2023-07-31T23:59:59+09:00", "2023-02-28"}:                          {},
-	{"", "2023-07-31T23:59:59+09:00", "2023-03-12"}:                          {},
-	{"", "2023-07-31T23:59:59+09:00", "2023-07-19"}:                          {},
-	{"", "2023-07-31T23:59:59-07:00", "2016-12-31"}:                          {},
-	{"", "2023-07-31T23:59:59-07:00", "2022-01-01"}:                          {},
-	{"", "2023-07-31T23:59:59-07:00", "2023-02-28"}:                          {},
-	{"", "2023-07-31T23:59:59-07:00", "2023-03-12"}:                          {},
-	{"", "2023-07-31T23:59:59-07:00", "2023-07-19"}:                          {},
-	{"", "2023-07-31T23:59:59Z", "2016-12-31"}:                               {},
-	{"", "2023-07-31T23:59:59Z", "2022-01-01"}:                               {},
-	{"", "2023-07-31T23:59:59Z", "2023-02-28"}:                               {},
-	{"", "2023-07-31T23:59:59Z", "2023-03-12"}:                               {},
-	{"", "2023-07-31T23:59:59Z", "2023-07-19"}:                               {},
-	{"", "2023-11-05T02:00:00-08:00", "2016-12-31"}:                          {},
-	{"", "2023-11-05T02:00:00-08:00", "2022-01-01"}:                          {},
-	{"", "2023-11-05T02:00:00-08:00", "2023-02-28"}:                          {},
-	{"", "2023-11-05T02:00:00-08:00", "2023-03-12"}:                          {},
-	{"", "2023-11-05T02:00:00-08:00", "2023-07-19"}:                          {},
-	{"", "2023-12-31T23:59:59Z", "2016-12-31"}:                               {},
-	{"", "2023-12-31T23:59:59Z", "2022-01-01"}:                               {},
-	{"", "2023-12-31T23:59:59Z", "2023-02-28"}:                               {},
-	{"", "2023-12-31T23:59:59Z", "2023-03-12"}:                               {},
-	{"", "2023-12-31T23:59:59Z", "2023-07-19"}:                               {},
-	{"", "2023-12-31T23:59:59Z", "2023-11-05"}:                               {},
-	{"", "2024-01-31T23:59:59Z", "2016-12-31"}:                               {},
-	{"", "2024-01-31T23:59:59Z", "2022-01-01"}:                               {},
-	{"", "2024-01-31T23:59:59Z", "2023-02-28"}:                               {},
-	{"", "2024-01-31T23:59:59Z", "2023-03-12"}:                               {},
-	{"", "2024-01-31T23:59:59Z", "2023-07-19"}:                               {},
-	{"", "2024-01-31T23:59:59Z", "2023-11-05"}:                               {},
-	{"", "2024-01-31T23:59:59Z", "2023-12-31"}:                               {},
-	{"", "2024-01-31T23:59:59Z", "2024-01-01"}:                               {},
-	{"", "2024-02-29T23:59:59-08:00", "2016-12-31"}:                          {},
-	{"", "2024-02-29T23:59:59-08:00", "2022-01-01"}:                          {},
-	{"", "2024-02-29T23:59:59-08:00", "2023-02-28"}:                          {},
-	{"", "2024-02-29T23:59:59-08:00", "2023-03-12"}:                          {},
-	{"", "2024-02-29T23:59:59-08:00", "2023-07-19"}:                          {},
-	{"", "2024-02-29T23:59:59-08:00", "2023-11-05"}:                          {},
-	{"", "2024-02-29T23:59:59-08:00", "2023-12-31"}:                          {},
-	{"", "2024-02-29T23:59:59-08:00", "2024-01-01"}:                          {},
-	{"", "2024-02-29T23:59:59-08:00", "2024-02-29"}:                          {},
-	{"", "2024-02-29T23:59:59Z", "2016-12-31"}:                               {},
-	{"", "2024-02-29T23:59:59Z", "2022-01-01"}:                               {},
-	{"", "2024-02-29T23:59:59Z", "2023-02-28"}:                               {},
-	{"", "2024-02-29T23:59:59Z", "2023-03-12"}:                               {},
-	{"", "2024-02-29T23:59:59Z", "2023-07-19"}:                               {},
-	{"", "2024-02-29T23:59:59Z", "2023-11-05"}:                               {},
-	{"", "2024-02-29T23:59:59Z", "2023-12-31"}:                               {},
-	{"", "2024-02-29T23:59:59Z", "2024-01-01"}:                               {},
-	{"", "2024-03-11T23:59:59-07:00", "2016-12-31"}:                          {},
-	{"", "2024-03-11T23:59:59-07:00", "2022-01-01"}:                          {},
-	{"", "2024-03-11T23:59:59-07:00", "2023-02-28"}:                          {},
-	{"", "2024-03-11T23:59:59-07:00", "2023-03-12"}:                          {},
-	{"", "2024-03-11T23:59:59-07:00", "2023-07-19"}:                          {},
-	{"", "2024-03-11T23:59:59-07:00", "2023-11-05"}:                          {},
-	{"", "2024-03-11T23:59:59-07:00", "2023-12-31"}:                          {},
-	{"", "2024-03-11T23:59:59-07:00", "2024-01-01"}:                          {},
-	{"", "2024-03-11T23:59:59-07:00", "2024-02-29"}:                          {},
-	{"", "2024-03-11T23:59:59-07:00", "2024-03-10"}:                          {},
-	{"", "2024-06-15T23:59:59+09:00", "2016-12-31"}:                          {},
-	{"", "2024-06-15T23:59:59+09:00", "2022-01-01"}:                          {},
-	{"", "2024-06-15T23:59:59+09:00", "2023-02-28"}:                          {},
-	{"", "2024-06-15T23:59:59+09:00", "2023-03-12"}:                          {},
-	{"", "2024-06-15T23:59:59+09:00", "2023-07-19"}:                          {},
-	{"", "2024-06-15T23:59:59+09:00", "2023-11-05"}:                          {},
-	{"", "2024-06-15T23:59:59+09:00", "2023-12-31"}:                          {},
-	{"", "2024-06-15T23:59:59+09:00", "2024-01-01"}:                          {},
-	{"", "2024-06-15T23:59:59+09:00", "2024-02-29"}:                          {},
-	{"", "2024-06-15T23:59:59+09:00", "2024-03-10"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2016-12-31"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2022-01-01"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2023-02-28"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2023-03-12"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2023-07-19"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2023-11-05"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2023-12-31"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2024-01-01"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2024-02-29"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2024-03-10"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2024-06-15"}:                          {},
-	{"", "2024-07-04T23:59:59-07:00", "2024-07-04"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2016-12-31"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2022-01-01"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2023-02-28"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2023-03-12"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2023-07-19"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2023-11-05"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2023-12-31"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2024-01-01"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2024-02-29"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2024-03-10"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2024-06-15"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2024-07-04"}:                          {},
-	{"", "2024-08-30T23:59:59-04:00", "2024-08-30"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2016-12-31"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2022-01-01"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2023-02-28"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2023-03-12"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2023-07-19"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2023-11-05"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2023-12-31"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2024-01-01"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2024-02-29"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2024-03-10"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2024-06-15"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2024-07-04"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2024-08-30"}:                          {},
-	{"", "2024-09-22T23:59:59-03:00", "2024-09-21"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2016-12-31"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2022-01-01"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2023-02-28"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2023-03-12"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2023-07-19"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2023-11-05"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2023-12-31"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2024-01-01"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2024-02-29"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2024-03-10"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2024-06-15"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2024-07-04"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2024-08-30"}:                          {},
-	{"", "2024-10-31T23:59:59+00:00", "2024-09-21"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2016-12-31"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2022-01-01"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2023-02-28"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2023-03-12"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2023-07-19"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2023-11-05"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2023-12-31"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2024-01-01"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2024-02-29"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2024-03-10"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2024-06-15"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2024-07-04"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2024-08-30"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2024-09-21"}:                          {},
-	{"", "2024-11-05T23:59:59+01:00", "2024-10-31"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2016-12-31"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2022-01-01"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2023-02-28"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2023-03-12"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2023-07-19"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2023-11-05"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2023-12-31"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2024-01-01"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2024-02-29"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2024-03-10"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2024-06-15"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2024-07-04"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2024-08-30"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2024-09-21"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2024-10-31"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2024-11-05"}:                          {},
-	{"", "2024-12-26T23:59:59+01:00", "2024-12-25"}:                          {},
-	{"2016-12-31T23:59:59Z", "", "2022-01-01"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2023-02-28"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2023-03-12"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2023-07-19"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2023-11-05"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2023-12-31"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2024-01-01"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2024-02-29"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2024-03-10"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2024-06-15"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2024-07-04"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2024-08-30"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2024-09-21"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2024-10-31"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2024-11-05"}:                               {},
-	{"2016-12-31T23:59:59Z", "", "2024-12-25"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2022-01-01"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2023-02-28"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2023-03-12"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2023-07-19"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2023-11-05"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2023-12-31"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2024-01-01"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2024-02-29"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2024-03-10"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2024-06-15"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2024-07-04"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2024-08-30"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2024-09-21"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2024-10-31"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2024-11-05"}:                               {},
-	{"2022-01-01T00:00:00Z", "", "2024-12-25"}:                               {},
-	{"2022-01-01T00:00:00Z", "2022-01-31T23:59:59Z", "2022-01-01"}:           {},
-	{"2023-02-01T00:00:00Z", "", "2023-02-28"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2023-03-12"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2023-07-19"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2023-11-05"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2023-12-31"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2024-01-01"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2024-02-29"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2024-03-10"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2024-06-15"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2024-07-04"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2024-08-30"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2024-09-21"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2024-10-31"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2024-11-05"}:                               {},
-	{"2023-02-01T00:00:00Z", "", "2024-12-25"}:                               {},
-	{"2023-03-12T01:59:59-07:00", "", "2023-07-19"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2023-11-05"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2023-12-31"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2024-01-01"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2024-02-29"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2024-03-10"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2024-06-15"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2024-07-04"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2024-08-30"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2024-09-21"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2024-10-31"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2024-11-05"}:                          {},
-	{"2023-03-12T01:59:59-07:00", "", "2024-12-25"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2023-07-19"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2023-11-05"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2023-12-31"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2024-01-01"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2024-02-29"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2024-03-10"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2024-06-15"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2024-07-04"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2024-08-30"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2024-09-21"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2024-10-31"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2024-11-05"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "", "2024-12-25"}:                          {},
-	{"2023-07-01T00:00:00+09:00", "2023-07-31T23:59:59+09:00", "2023-07-19"}: {},
-	{"2023-07-01T00:00:00-07:00", "", "2023-07-19"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2023-11-05"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2023-12-31"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2024-01-01"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2024-02-29"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2024-03-10"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2024-06-15"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2024-07-04"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2024-08-30"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2024-09-21"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2024-10-31"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2024-11-05"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "", "2024-12-25"}:                          {},
-	{"2023-07-01T00:00:00-07:00", "2023-07-31T23:59:59-07:00", "2023-07-19"}: {},
-	{"2023-07-01T00:00:00Z", "", "2023-07-19"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2023-11-05"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2023-12-31"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2024-01-01"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2024-02-29"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2024-03-10"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2024-06-15"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2024-07-04"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2024-08-30"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2024-09-21"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2024-10-31"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2024-11-05"}:                               {},
-	{"2023-07-01T00:00:00Z", "", "2024-12-25"}:                               {},
-	{"2023-07-01T00:00:00Z", "2023-07-31T23:59:59Z", "2023-07-19"}:           {},
-	{"2023-11-05T00:59:59-07:00", "", "2023-12-31"}:                          {},
-	{"2023-11-05T00:59:59-07:00", "", "2024-01-01"}:                          {},
-	{"2023-11-05T00:59:59-07:00", "", "2024-02-29"}:                          {},
-	{"2023-11-05T00:59:59-07:00", "", "2024-03-10"}:                          {},
-	{"2023-11-05T00:59:59-07:00", "", "2024-06-15"}:                          {},
-	{"2023-11-05T00:59:59-07:00", "", "2024-07-04"}:                          {},
-	{"2023-11-05T00:59:59-07:00", "", "2024-08-30"}:                          {},
-	{"2023-11-05T00:59:59-07:00", "", "2024-09-21"}:                          {},
-	{"2023-11-05T00:59:59-07:00", "", "2024-10-31"}:                          {},
-	{"2023-11-05T00:59:59-07:00", "", "2024-11-05"}:                          {},
-	{"2023-11-05T00:59:59-07:00", "", "2024-12-25"}:                          {},
-	{"2023-12-01T00:00:00Z", "", "2023-12-31"}:                               {},
-	{"2023-12-01T00:00:00Z", "", "2024-01-01"}:                               {},
-	{"2023-12-01T00:00:00Z", "", "2024-02-29"}:                               {},
-	{"2023-12-01T00:00:00Z", "", "2024-03-10"}:                               {},
-	{"2023-12-01T00:00:00Z", "", "2024-06-15"}:                               {},
-	{"2023-12-01T00:00:00Z", "", "2024-07-04"}:                               {},
-	{"2023-12-01T00:00:00Z", "", "2024-08-30"}:                               {},
-	{"2023-12-01T00:00:00Z", "", "2024-09-21"}:                               {},
-	{"2023-12-01T00:00:00Z", "", "2024-10-31"}:                               {},
-	{"2023-12-01T00:00:00Z", "", "2024-11-05"}:                               {},
-	{"2023-12-01T00:00:00Z", "", "2024-12-25"}:                               {},
-	{"2024-01-01T00:00:00Z", "", "2024-01-01"}:                               {},
-	{"2024-01-01T00:00:00Z", "", "2024-02-29"}:                               {},
-	{"2024-01-01T00:00:00Z", "", "2024-03-10"}:                               {},
-	{"2024-01-01T00:00:00Z", "", "2024-06-15"}:                               {},
-	{"2024-01-01T00:00:00Z", "", "2024-07-04"}:                               {},
-	{"2024-01-01T00:00:00Z", "", "2024-08-30"}:                               {},
-	{"2024-01-01T00:00:00Z", "", "2024-09-21"}:                               {},
-	{"2024-01-01T00:00:00Z", "", "2024-10-31"}:                               {},
-	{"2024-01-01T00:00:00Z", "", "2024-11-05"}:                               {},
-	{"2024-01-01T00:00:00Z", "", "2024-12-25"}:                               {},
-	{"2024-01-01T00:00:00Z", "2024-01-31T23:59:59Z", "2024-01-01"}:           {},
-	{"2024-02-01T00:00:00-08:00", "", "2024-02-29"}:                          {},
-	{"2024-02-01T00:00:00-08:00", "", "2024-03-10"}:                          {},
-	{"2024-02-01T00:00:00-08:00", "", "2024-06-15"}:                          {},
-	{"2024-02-01T00:00:00-08:00", "", "2024-07-04"}:                          {},
-	{"2024-02-01T00:00:00-08:00", "", "2024-08-30"}:                          {},
-	{"2024-02-01T00:00:00-08:00", "", "2024-09-21"}:                          {},
-	{"2024-02-01T00:00:00-08:00", "", "2024-10-31"}:                          {},
-	{"2024-02-01T00:00:00-08:00", "", "2024-11-05"}:                          {},
-	{"2024-02-01T00:00:00-08:00", "", "2024-12-25"}:                          {},
-	{"2024-02-01T00:00:00-08:00", "2024-02-29T23:59:59-08:00", "2024-02-29"}: {},
-	{"2024-02-01T00:00:00Z", "", "2024-02-29"}:                               {},
-	{"2024-02-01T00:00:00Z", "", "2024-03-10"}:                               {},
-	{"2024-02-01T00:00:00Z", "", "2024-06-15"}:                               {},
-	{"2024-02-01T00:00:00Z", "", "2024-07-04"}:                               {},
-	{"2024-02-01T00:00:00Z", "", "2024-08-30"}:                               {},
-	{"2024-02-01T00:00:00Z", "", "2024-09-21"}:                               {},
-	{"2024-02-01T00:00:00Z", "", "2024-10-31"}:                               {},
-	{"2024-02-01T00:00:00Z", "", "2024-11-05"}:                               {},
-	{"2024-02-01T00:00:00Z", "", "2024-12-25"}:                               {},
-	{"2024-03-09T00:00:00-08:00", "", "2024-03-10"}:                          {},
-	{"2024-03-09T00:00:00-08:00", "", "2024-06-15"}:                          {},
-	{"2024-03-09T00:00:00-08:00", "", "2024-07-04"}:                          {},
-	{"2024-03-09T00:00:00-08:00", "", "2024-08-30"}:                          {},
-	{"2024-03-09T00:00:00-08:00", "", "2024-09-21"}:                          {},
-	{"2024-03-09T00:00:00-08:00", "", "2024-10-31"}:                          {},
-	{"2024-03-09T00:00:00-08:00", "", "2024-11-05"}:                          {},
-	{"2024-03-09T00:00:00-08:00", "", "2024-12-25"}:                          {},
-	{"2024-03-09T00:00:00-08:00", "2024-03-11T23:59:59-07:00", "2024-03-10"}: {},
-	{"2024-06-01T00:00:00+09:00", "", "2024-06-15"}:                          {},
-	{"2024-06-01T00:00:00+09:00", "", "2024-07-04"}:                          {},
-	{"2024-06-01T00:00:00+09:00", "", "2024-08-30"}:                          {},
-	{"2024-06-01T00:00:00+09:00", "", "2024-09-21"}:                          {},
-	{"2024-06-01T00:00:00+09:00", "", "2024-10-31"}:                          {},
-	{"2024-06-01T00:00:00+09:00", "", "2024-11-05"}:                          {},
-	{"2024-06-01T00:00:00+09:00", "", "2024-12-25"}:                          {},
-	{"2024-07-01T00:00:00-07:00", "", "2024-07-04"}:                          {},
-	{"2024-07-01T00:00:00-07:00", "", "2024-08-30"}:                          {},
-	{"2024-07-01T00:00:00-07:00", "", "2024-09-21"}:                          {},
-	{"2024-07-01T00:00:00-07:00", "", "2024-10-31"}:                          {},
-	{"2024-07-01T00:00:00-07:00", "", "2024-11-05"}:                          {},
-	{"2024-07-01T00:00:00-07:00", "", "2024-12-25"}:                          {},
-	{"2024-07-01T00:00:00-07:00", "2024-07-04T23:59:59-07:00", "2024-07-04"}: {},
-	{"2024-08-15T00:00:00-04:00", "", "2024-08-30"}:                          {},
-	{"2024-08-15T00:00:00-04:00", "", "2024-09-21"}:                          {},
-	{"2024-08-15T00:00:00-04:00", "", "2024-10-31"}:                          {},
-	{"2024-08-15T00:00:00-04:00", "", "2024-11-05"}:                          {},
-	{"2024-08-15T00:00:00-04:00", "", "2024-12-25"}:                          {},
-	{"2024-08-15T00:00:00-04:00", "2024-08-30T23:59:59-04:00", "2024-08-30"}: {},
-	{"2024-09-20T00:00:00-03:00", "", "2024-09-21"}:                          {},
-	{"2024-09-20T00:00:00-03:00", "", "2024-10-31"}:                          {},
-	{"2024-09-20T00:00:00-03:00", "", "2024-11-05"}:                          {},
-	{"2024-09-20T00:00:00-03:00", "", "2024-12-25"}:                          {},
-	{"2024-09-20T00:00:00-03:00", "2024-09-22T23:59:59-03:00", "2024-09-21"}: {},
-	{"2024-10-01T00:00:00+00:00", "", "2024-10-31"}:                          {},
-	{"2024-10-01T00:00:00+00:00", "", "2024-11-05"}:                          {},
-	{"2024-10-01T00:00:00+00:00", "", "2024-12-25"}:                          {},
-	{"2024-11-01T00:00:00+01:00", "", "2024-11-05"}:                          {},
-	{"2024-11-01T00:00:00+01:00", "", "2024-12-25"}:                          {},
-	{"2024-12-24T00:00:00+01:00", "", "2024-12-25"}:                          {},
-	{"2024-12-24T00:00:00+01:00", "2024-12-26T23:59:59+01:00", "2024-12-25"}: {},
-
-	// for DateToTimestamp
-
-	{"", "2022-01-31", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2023-02-28", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2023-02-28", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2023-03-12", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2023-03-12", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2023-03-12", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2023-07-31", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2023-07-31", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2023-07-31", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2023-07-31", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2023-07-31", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2023-07-31", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2023-11-06", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2023-11-06", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2023-11-06", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2023-11-06", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2023-11-06", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2023-11-06", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2023-11-06", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2023-12-31", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2023-12-31", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2023-12-31", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2023-12-31", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2023-12-31", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2023-12-31", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2023-12-31", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2023-12-31", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-01-31", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2024-01-31", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2024-01-31", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2024-01-31", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2024-01-31", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2024-01-31", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2024-01-31", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2024-01-31", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-01-31", "2024-01-01T00:00:00Z"}:                {},
-	{"", "2024-02-29", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2024-02-29", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2024-02-29", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2024-02-29", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2024-02-29", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2024-02-29", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2024-02-29", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2024-02-29", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-02-29", "2024-01-01T00:00:00Z"}:                {},
-	{"", "2024-02-29", "2024-02-29T12:00:00+05:30"}:           {},
-	{"", "2024-02-29", "2024-02-29T12:00:00Z"}:                {},
-	{"", "2024-03-11", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2024-03-11", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2024-03-11", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2024-03-11", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2024-03-11", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2024-03-11", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2024-03-11", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2024-03-11", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-03-11", "2024-01-01T00:00:00Z"}:                {},
-	{"", "2024-03-11", "2024-02-29T12:00:00+05:30"}:           {},
-	{"", "2024-03-11", "2024-02-29T12:00:00Z"}:                {},
-	{"", "2024-03-11", "2024-03-10T02:00:00-08:00"}:           {},
-	{"", "2024-06-15", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2024-06-15", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2024-06-15", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2024-06-15", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2024-06-15", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2024-06-15", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2024-06-15", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2024-06-15", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-06-15", "2024-01-01T00:00:00Z"}:                {},
-	{"", "2024-06-15", "2024-02-29T12:00:00+05:30"}:           {},
-	{"", "2024-06-15", "2024-02-29T12:00:00Z"}:                {},
-	{"", "2024-06-15", "2024-03-10T02:00:00-08:00"}:           {},
-	{"", "2024-06-15", "2024-06-15T13:45:30+09:00"}:           {},
-	{"", "2024-07-04", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2024-07-04", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2024-07-04", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2024-07-04", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2024-07-04", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2024-07-04", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2024-07-04", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2024-07-04", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-07-04", "2024-01-01T00:00:00Z"}:                {},
-	{"", "2024-07-04", "2024-02-29T12:00:00+05:30"}:           {},
-	{"", "2024-07-04", "2024-02-29T12:00:00Z"}:                {},
-	{"", "2024-07-04", "2024-03-10T02:00:00-08:00"}:           {},
-	{"", "2024-07-04", "2024-06-15T13:45:30+09:00"}:           {},
-	{"", "2024-08-30", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2024-08-30", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2024-08-30", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2024-08-30", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2024-08-30", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2024-08-30", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2024-08-30", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2024-08-30", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-08-30", "2024-01-01T00:00:00Z"}:                {},
-	{"", "2024-08-30", "2024-02-29T12:00:00+05:30"}:           {},
-	{"", "2024-08-30", "2024-02-29T12:00:00Z"}:                {},
-	{"", "2024-08-30", "2024-03-10T02:00:00-08:00"}:           {},
-	{"", "2024-08-30", "2024-06-15T13:45:30+09:00"}:           {},
-	{"", "2024-08-30", "2024-07-04T23:59:59-07:00"}:           {},
-	{"", "2024-08-30", "2024-08-30T18:30:00-04:00"}:           {},
-	{"", "2024-09-22", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2024-09-22", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2024-09-22", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2024-09-22", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2024-09-22", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2024-09-22", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2024-09-22", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2024-09-22", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-09-22", "2024-01-01T00:00:00Z"}:                {},
-	{"", "2024-09-22", "2024-02-29T12:00:00+05:30"}:           {},
-	{"", "2024-09-22", "2024-02-29T12:00:00Z"}:                {},
-	{"", "2024-09-22", "2024-03-10T02:00:00-08:00"}:           {},
-	{"", "2024-09-22", "2024-06-15T13:45:30+09:00"}:           {},
-	{"", "2024-09-22", "2024-07-04T23:59:59-07:00"}:           {},
-	{"", "2024-09-22", "2024-08-30T18:30:00-04:00"}:           {},
-	{"", "2024-09-22", "2024-09-21T00:00:00-03:00"}:           {},
-	{"", "2024-10-31", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2024-10-31", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2024-10-31", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2024-10-31", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2024-10-31", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2024-10-31", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2024-10-31", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2024-10-31", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-10-31", "2024-01-01T00:00:00Z"}:                {},
-	{"", "2024-10-31", "2024-02-29T12:00:00+05:30"}:           {},
-	{"", "2024-10-31", "2024-02-29T12:00:00Z"}:                {},
-	{"", "2024-10-31", "2024-03-10T02:00:00-08:00"}:           {},
-	{"", "2024-10-31", "2024-06-15T13:45:30+09:00"}:           {},
-	{"", "2024-10-31", "2024-07-04T23:59:59-07:00"}:           {},
-	{"", "2024-10-31", "2024-08-30T18:30:00-04:00"}:           {},
-	{"", "2024-10-31", "2024-09-21T00:00:00-03:00"}:           {},
-	{"", "2024-10-31", "2024-10-31T17:00:00+00:00"}:           {},
-	{"", "2024-11-05", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2024-11-05", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2024-11-05", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2024-11-05", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2024-11-05", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2024-11-05", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2024-11-05", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2024-11-05", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-11-05", "2024-01-01T00:00:00Z"}:                {},
-	{"", "2024-11-05", "2024-02-29T12:00:00+05:30"}:           {},
-	{"", "2024-11-05", "2024-02-29T12:00:00Z"}:                {},
-	{"", "2024-11-05", "2024-03-10T02:00:00-08:00"}:           {},
-	{"", "2024-11-05", "2024-06-15T13:45:30+09:00"}:           {},
-	{"", "2024-11-05", "2024-07-04T23:59:59-07:00"}:           {},
-	{"", "2024-11-05", "2024-08-30T18:30:00-04:00"}:           {},
-	{"", "2024-11-05", "2024-09-21T00:00:00-03:00"}:           {},
-	{"", "2024-11-05", "2024-10-31T17:00:00+00:00"}:           {},
-	{"", "2024-11-05", "2024-11-05T08:00:00+01:00"}:           {},
-	{"", "2024-12-26", "2022-01-01T00:00:00Z"}:                {},
-	{"", "2024-12-26", "2023-02-28T23:59:59Z"}:                {},
-	{"", "2024-12-26", "2023-03-12T02:00:00-07:00"}:           {},
-	{"", "2024-12-26", "2023-07-19T14:30:00+09:00"}:           {},
-	{"", "2024-12-26", "2023-07-19T14:30:00-07:00"}:           {},
-	{"", "2024-12-26", "2023-07-19T14:30:00Z"}:                {},
-	{"", "2024-12-26", "2023-11-05T01:00:00-08:00"}:           {},
-	{"", "2024-12-26", "2023-12-31T23:59:59Z"}:                {},
-	{"", "2024-12-26", "2024-01-01T00:00:00Z"}:                {},
-	{"", "2024-12-26", "2024-02-29T12:00:00+05:30"}:           {},
-	{"", "2024-12-26", "2024-02-29T12:00:00Z"}:                {},
-	{"", "2024-12-26", "2024-03-10T02:00:00-08:00"}:           {},
-	{"", "2024-12-26", "2024-06-15T13:45:30+09:00"}:           {},
-	{"", "2024-12-26", "2024-07-04T23:59:59-07:00"}:           {},
-	{"", "2024-12-26", "2024-08-30T18:30:00-04:00"}:           {},
-	{"", "2024-12-26", "2024-09-21T00:00:00-03:00"}:           {},
-	{"", "2024-12-26", "2024-10-31T17:00:00+00:00"}:           {},
-	{"", "2024-12-26", "2024-11-05T08:00:00+01:00"}:           {},
-	{"", "2024-12-26", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2016-12-31", "", "2022-01-01T00:00:00Z"}:                {},
-	{"2016-12-31", "", "2023-02-28T23:59:59Z"}:                {},
-	{"2016-12-31", "", "2023-03-12T02:00:00-07:00"}:           {},
-	{"2016-12-31", "", "2023-07-19T14:30:00+09:00"}:           {},
-	{"2016-12-31", "", "2023-07-19T14:30:00-07:00"}:           {},
-	{"2016-12-31", "", "2023-07-19T14:30:00Z"}:                {},
-	{"2016-12-31", "", "2023-11-05T01:00:00-08:00"}:           {},
-	{"2016-12-31", "", "2023-12-31T23:59:59Z"}:                {},
-	{"2016-12-31", "", "2024-01-01T00:00:00Z"}:                {},
-	{"2016-12-31", "", "2024-02-29T12:00:00+05:30"}:           {},
-	{"2016-12-31", "", "2024-02-29T12:00:00Z"}:                {},
-	{"2016-12-31", "", "2024-03-10T02:00:00-08:00"}:           {},
-	{"2016-12-31", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2016-12-31", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2016-12-31", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2016-12-31", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2016-12-31", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2016-12-31", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2016-12-31", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2022-01-01", "", "2022-01-01T00:00:00Z"}:                {},
-	{"2022-01-01", "", "2023-02-28T23:59:59Z"}:                {},
-	{"2022-01-01", "", "2023-03-12T02:00:00-07:00"}:           {},
-	{"2022-01-01", "", "2023-07-19T14:30:00+09:00"}:           {},
-	{"2022-01-01", "", "2023-07-19T14:30:00-07:00"}:           {},
-	{"2022-01-01", "", "2023-07-19T14:30:00Z"}:                {},
-	{"2022-01-01", "", "2023-11-05T01:00:00-08:00"}:           {},
-	{"2022-01-01", "", "2023-12-31T23:59:59Z"}:                {},
-	{"2022-01-01", "", "2024-01-01T00:00:00Z"}:                {},
-	{"2022-01-01", "", "2024-02-29T12:00:00+05:30"}:           {},
-	{"2022-01-01", "", "2024-02-29T12:00:00Z"}:                {},
-	{"2022-01-01", "", "2024-03-10T02:00:00-08:00"}:           {},
-	{"2022-01-01", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2022-01-01", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2022-01-01", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2022-01-01", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2022-01-01", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2022-01-01", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2022-01-01", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2022-01-01", "2022-01-31", "2022-01-01T00:00:00Z"}:      {},
-	{"2023-02-01", "", "2023-02-28T23:59:59Z"}:                {},
-	{"2023-02-01", "", "2023-03-12T02:00:00-07:00"}:           {},
-	{"2023-02-01", "", "2023-07-19T14:30:00+09:00"}:           {},
-	{"2023-02-01", "", "2023-07-19T14:30:00-07:00"}:           {},
-	{"2023-02-01", "", "2023-07-19T14:30:00Z"}:                {},
-	{"2023-02-01", "", "2023-11-05T01:00:00-08:00"}:           {},
-	{"2023-02-01", "", "2023-12-31T23:59:59Z"}:                {},
-	{"2023-02-01", "", "2024-01-01T00:00:00Z"}:                {},
-	{"2023-02-01", "", "2024-02-29T12:00:00+05:30"}:           {},
-	{"2023-02-01", "", "2024-02-29T12:00:00Z"}:                {},
-	{"2023-02-01", "", "2024-03-10T02:00:00-08:00"}:           {},
-	{"2023-02-01", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2023-02-01", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2023-02-01", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2023-02-01", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2023-02-01", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2023-02-01", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2023-02-01", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2023-02-01", "2023-02-28", "2023-02-28T23:59:59Z"}:      {},
-	{"2023-03-10", "", "2023-03-12T02:00:00-07:00"}:           {},
-	{"2023-03-10", "", "2023-07-19T14:30:00+09:00"}:           {},
-	{"2023-03-10", "", "2023-07-19T14:30:00-07:00"}:           {},
-	{"2023-03-10", "", "2023-07-19T14:30:00Z"}:                {},
-	{"2023-03-10", "", "2023-11-05T01:00:00-08:00"}:           {},
-	{"2023-03-10", "", "2023-12-31T23:59:59Z"}:                {},
-	{"2023-03-10", "", "2024-01-01T00:00:00Z"}:                {},
-	{"2023-03-10", "", "2024-02-29T12:00:00+05:30"}:           {},
-	{"2023-03-10", "", "2024-02-29T12:00:00Z"}:                {},
-	{"2023-03-10", "", "2024-03-10T02:00:00-08:00"}:           {},
-	{"2023-03-10", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2023-03-10", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2023-03-10", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2023-03-10", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2023-03-10", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2023-03-10", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2023-03-10", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2023-03-10", "2023-03-12", "2023-03-12T02:00:00-07:00"}: {},
-	{"2023-07-01", "", "2023-07-19T14:30:00+09:00"}:           {},
-	{"2023-07-01", "", "2023-07-19T14:30:00-07:00"}:           {},
-	{"2023-07-01", "", "2023-07-19T14:30:00Z"}:                {},
-	{"2023-07-01", "", "2023-11-05T01:00:00-08:00"}:           {},
-	{"2023-07-01", "", "2023-12-31T23:59:59Z"}:                {},
-	{"2023-07-01", "", "2024-01-01T00:00:00Z"}:                {},
-	{"2023-07-01", "", "2024-02-29T12:00:00+05:30"}:           {},
-	{"2023-07-01", "", "2024-02-29T12:00:00Z"}:                {},
-	{"2023-07-01", "", "2024-03-10T02:00:00-08:00"}:           {},
-	{"2023-07-01", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2023-07-01", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2023-07-01", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2023-07-01", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2023-07-01", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2023-07-01", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2023-07-01", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2023-07-01", "2023-07-31", "2023-07-19T14:30:00+09:00"}: {},
-	{"2023-07-01", "2023-07-31", "2023-07-19T14:30:00-07:00"}: {},
-	{"2023-07-01", "2023-07-31", "2023-07-19T14:30:00Z"}:      {},
-	{"2023-11-04", "", "2023-11-05T01:00:00-08:00"}:           {},
-	{"2023-11-04", "", "2023-12-31T23:59:59Z"}:                {},
-	{"2023-11-04", "", "2024-01-01T00:00:00Z"}:                {},
-	{"2023-11-04", "", "2024-02-29T12:00:00+05:30"}:           {},
-	{"2023-11-04", "", "2024-02-29T12:00:00Z"}:                {},
-	{"2023-11-04", "", "2024-03-10T02:00:00-08:00"}:           {},
-	{"2023-11-04", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2023-11-04", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2023-11-04", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2023-11-04", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2023-11-04", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2023-11-04", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2023-11-04", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2023-11-04", "2023-11-06", "2023-11-05T01:00:00-08:00"}: {},
-	{"2023-12-01", "", "2023-12-31T23:59:59Z"}:                {},
-	{"2023-12-01", "", "2024-01-01T00:00:00Z"}:                {},
-	{"2023-12-01", "", "2024-02-29T12:00:00+05:30"}:           {},
-	{"2023-12-01", "", "2024-02-29T12:00:00Z"}:                {},
-	{"2023-12-01", "", "2024-03-10T02:00:00-08:00"}:           {},
-	{"2023-12-01", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2023-12-01", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2023-12-01", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2023-12-01", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2023-12-01", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2023-12-01", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2023-12-01", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2023-12-01", "2023-12-31", "2023-12-31T23:59:59Z"}:      {},
-	{"2024-01-01", "", "2024-01-01T00:00:00Z"}:                {},
-	{"2024-01-01", "", "2024-02-29T12:00:00+05:30"}:           {},
-	{"2024-01-01", "", "2024-02-29T12:00:00Z"}:                {},
-	{"2024-01-01", "", "2024-03-10T02:00:00-08:00"}:           {},
-	{"2024-01-01", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2024-01-01", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2024-01-01", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2024-01-01", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2024-01-01", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2024-01-01", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2024-01-01", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2024-01-01", "2024-01-31", "2024-01-01T00:00:00Z"}:      {},
-	{"2024-02-01", "", "2024-02-29T12:00:00+05:30"}:           {},
-	{"2024-02-01", "", "2024-02-29T12:00:00Z"}:                {},
-	{"2024-02-01", "", "2024-03-10T02:00:00-08:00"}:           {},
-	{"2024-02-01", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2024-02-01", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2024-02-01", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2024-02-01", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2024-02-01", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2024-02-01", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2024-02-01", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2024-02-01", "2024-02-29", "2024-02-29T12:00:00+05:30"}: {},
-	{"2024-02-01", "2024-02-29", "2024-02-29T12:00:00Z"}:      {},
-	{"2024-03-09", "", "2024-03-10T02:00:00-08:00"}:           {},
-	{"2024-03-09", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2024-03-09", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2024-03-09", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2024-03-09", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2024-03-09", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2024-03-09", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2024-03-09", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2024-03-09", "2024-03-11", "2024-03-10T02:00:00-08:00"}: {},
-	{"2024-06-01", "", "2024-06-15T13:45:30+09:00"}:           {},
-	{"2024-06-01", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2024-06-01", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2024-06-01", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2024-06-01", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2024-06-01", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2024-06-01", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2024-06-01", "2024-06-15", "2024-06-15T13:45:30+09:00"}: {},
-	{"2024-07-01", "", "2024-07-04T23:59:59-07:00"}:           {},
-	{"2024-07-01", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2024-07-01", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2024-07-01", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2024-07-01", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2024-07-01", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2024-08-15", "", "2024-08-30T18:30:00-04:00"}:           {},
-	{"2024-08-15", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2024-08-15", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2024-08-15", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2024-08-15", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2024-08-15", "2024-08-30", "2024-08-30T18:30:00-04:00"}: {},
-	{"2024-09-20", "", "2024-09-21T00:00:00-03:00"}:           {},
-	{"2024-09-20", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2024-09-20", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2024-09-20", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2024-09-20", "2024-09-22", "2024-09-21T00:00:00-03:00"}: {},
-	{"2024-10-01", "", "2024-10-31T17:00:00+00:00"}:           {},
-	{"2024-10-01", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2024-10-01", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2024-10-01", "2024-10-31", "2024-10-31T17:00:00+00:00"}: {},
-	{"2024-11-01", "", "2024-11-05T08:00:00+01:00"}:           {},
-	{"2024-11-01", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2024-11-01", "2024-11-05", "2024-11-05T08:00:00+01:00"}: {},
-	{"2024-12-24", "", "2024-12-25T00:00:00-05:00"}:           {},
-	{"2024-12-24", "2024-12-26", "2024-12-25T00:00:00-05:00"}: {},
-}
@@ -2,7 +2,10 @@ package baseline
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -166,12 +169,47 @@ func TestExampleTimestampToDate(t *testing.T) {
 	TestTimestampToDate(t, TimestampRangeValues, DateValues, ExampleMatches, ExampleTimestampToDate)
 }
 
+func TestTestTimestampToDateParallel(t *testing.T) {
+	var mu sync.Mutex
+	var calls, active, maxActive int
+	TestTimestampToDateParallel(t, 4, TimestampRangeValues, DateValues, ExampleMatches, func(startTime, endTime time.Time) (string, string) {
+		mu.Lock()
+		calls++
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return ExampleTimestampToDate(startTime, endTime)
+	})
+	// N.B. every case must have run, before it returns
+	var cases int
+	RangeTestCases(TimestampRangeValues, DateValues, func(r [2]string, v string) bool {
+		cases++
+		return true
+	})
+	if calls != cases || maxActive > 4 {
+		t.Errorf("expected %d calls, with at most 4 concurrent, got %d calls, with %d concurrent", cases, calls, maxActive)
+	}
+}
+
 func TestTestTimestampToDateExternal_yoDawg(t *testing.T) {
 	if err := TestTimestampToDateExternal(context.Background(), TimestampRangeValues, DateValues, ExampleMatches, ExampleTimestampToDate); err != nil {
 		t.Fatal(err)
 	}
 }
 
+func TestTestTimestampToDateExternal_nilContext(t *testing.T) {
+	if err := TestTimestampToDateExternal(nil, TimestampRangeValues, DateValues, ExampleMatches, ExampleTimestampToDate); err != nil {
+		t.Fatal(err)
+	}
+	if err := TestDateToTimestampExternal(nil, DateRangeValues, TimestampValues, ExampleMatches, ExampleDateToTimestamp); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestExampleDateToTimestamp(t *testing.T) {
 	TestDateToTimestamp(t, DateRangeValues, TimestampValues, ExampleMatches, ExampleDateToTimestamp)
 }
@@ -179,3 +217,130 @@ func TestExampleDateToTimestamp(t *testing.T) {
 func FuzzExampleTimestampToDate(f *testing.F) {
 	FuzzTimestampToDate(f, TimestampRangeValues, DateValues, ExampleTimestampToDate)
 }
+
+func TestTestDateToTimestampExternal_yoDawg(t *testing.T) {
+	if err := TestDateToTimestampExternal(context.Background(), DateRangeValues, TimestampValues, ExampleMatches, ExampleDateToTimestamp); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func FuzzExampleDateToTimestamp(f *testing.F) {
+	FuzzDateToTimestamp(f, DateRangeValues, TimestampValues, ExampleDateToTimestamp)
+}
+
+func TestTestTimestampToDateExternal_failures(t *testing.T) {
+	var calls int
+	convert := func(startTime, endTime time.Time) (startDate, endDate string) {
+		calls++
+		switch calls {
+		case 2:
+			panic(errors.New(`some failure`))
+		case 3:
+			return `invalid`, ``
+		}
+		return ExampleTimestampToDate(startTime, endTime)
+	}
+	err := TestTimestampToDateExternal(context.Background(), TimestampRangeValues[:1], DateValues, ExampleMatches, convert)
+	var verr *VerificationError
+	if !errors.As(err, &verr) {
+		t.Fatal(err)
+	}
+	// N.B. DateValues contains one duplicate, and each range has 3 variants
+	if verr.Failed != 2 || len(verr.Failures) != 2 || verr.Passed != 3*(len(DateValues)-1)-2 {
+		t.Fatalf("unexpected counts: %s", verr.Summary())
+	}
+	if f := verr.Failures[0]; f.Range != [2]string(TimestampRangeValues[0]) || f.Value != DateValues[1] || !strings.Contains(f.Err.Error(), `panic: some failure`) {
+		t.Errorf("unexpected failure: %s", f)
+	}
+	if f := verr.Failures[1]; f.Converted[0] != `invalid` || !strings.Contains(f.Err.Error(), `startDate error`) {
+		t.Errorf("unexpected failure: %s", f)
+	}
+}
+
+func TestTestDateToTimestampExternal_failures(t *testing.T) {
+	// treats the end date as exclusive
+	convert := func(startDate, endDate string) (startTime, endTime time.Time) {
+		startTime, endTime = ExampleDateToTimestamp(startDate, endDate)
+		if endTime != (time.Time{}) {
+			endTime = endTime.Add(-oneDay)
+		}
+		return
+	}
+	err := TestDateToTimestampExternal(context.Background(), DateRangeValues, TimestampValues, ExampleMatches, convert)
+	var verr *VerificationError
+	if !errors.As(err, &verr) {
+		t.Fatal(err)
+	}
+	if verr.Failed == 0 || verr.Failed != len(verr.Failures) || verr.Passed == 0 {
+		t.Fatalf("unexpected counts: %s", verr.Summary())
+	}
+	for _, f := range verr.Failures {
+		if !f.Expected || f.Actual || !strings.Contains(f.Err.Error(), `expected true, got false`) {
+			t.Errorf("unexpected failure: %s", f)
+		}
+	}
+	if !strings.HasPrefix(err.Error(), `verification failed: `+verr.Summary()) {
+		t.Error(err)
+	}
+}
+
+func TestDecodeTimestampToDateFuzzArgs(t *testing.T) {
+	// testdata/fuzz/FuzzTimestampToDate/a8b588b93d760eb1, of cmd/fuzz-timestamp-to-date
+	c, err := DecodeTimestampToDateFuzzArgs(int64(1704067200000000000), int(50), int64(1706745599000000078), int(-32400), int64(1704067200000000000), false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := c.String(); s != `[2024-01-01T00:00:00Z, 2024-01-31T14:59:59.000000078-09:00) matching 2024-01-01` {
+		t.Errorf("unexpected case: %s", s)
+	}
+	if err := CheckFuzzTimestampToDate(c, ExampleTimestampToDate); err != nil {
+		t.Error(err)
+	}
+
+	// offsets with seconds are encoded beyond a day, see encodeFuzzOffset
+	c, err = DecodeTimestampToDateFuzzArgs(int64(1704067200000000000), encodeFuzzOffset(50), int64(1706745599000000078), int(-86401), int64(1704067200000000000), false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := c.String(); s != `[2024-01-01T00:00:50+00:00:50, 2024-01-31T23:59:58.000000078-00:00:01) matching 2024-01-01` {
+		t.Errorf("unexpected case: %s", s)
+	}
+
+	if _, err := DecodeTimestampToDateFuzzArgs(int64(0), 0, int64(0), 0, int64(0), true, true); !errors.Is(err, ErrFuzzInputSkipped) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := DecodeTimestampToDateFuzzArgs(int64(0), 0, int64(1), 0, int64(0), false, false); !errors.Is(err, ErrFuzzInputSkipped) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := DecodeTimestampToDateFuzzArgs(int64(0), int64(0), int64(0), 0, int64(0), false, true); err == nil || errors.Is(err, ErrFuzzInputSkipped) || err.Error() != `fuzz argument 1: expected int, got int64` {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := DecodeTimestampToDateFuzzArgs(int64(0)); err == nil || errors.Is(err, ErrFuzzInputSkipped) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckFuzzTimestampToDate_failures(t *testing.T) {
+	c := TimestampToDateCase{
+		StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Value:     `2024-02-01`,
+	}
+	err := CheckFuzzTimestampToDate(c, inclusiveEndTimestampToDate)
+	if err == nil || !strings.Contains(err.Error(), "differed from baseline") || !strings.Contains(err.Error(), "expected true, got (false && false)") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = CheckFuzzTimestampToDate(c, func(startTime, endTime time.Time) (string, string) {
+		return `2024-01-01`, ``
+	})
+	if err == nil || !strings.Contains(err.Error(), "ignoreEnd=false, endDate=") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// malformed output is an error, not a panic
+	err = CheckFuzzTimestampToDate(c, func(startTime, endTime time.Time) (string, string) {
+		return `2024-01-01`, `2024-02-31`
+	})
+	if err == nil || !strings.Contains(err.Error(), `invalid endDate "2024-02-31"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+package baseline
+
+import (
+	"math/bits"
+	"time"
+)
+
+type (
+	// Bitmap is a set of indexes (bit i of word i/64), as used to report
+	// which of a slice of values matched a range.
+	Bitmap []uint64
+
+	// DateRange is a pre-parsed date range, [Start, End], for batch
+	// matching, equivalent to [MatchesDate]. The bounds are epoch dates, see
+	// [EpochUnit], and unset bounds are [UnboundedStartDate] and
+	// [UnboundedEndDate].
+	DateRange struct {
+		Start, End int32
+	}
+
+	// TimestampRange is a pre-converted timestamp range, [Start, End), for
+	// batch matching, equivalent to [MatchesTimestamp]. The bounds are epoch
+	// timestamps, in the same [EpochUnit] as the values being matched, and
+	// unset bounds are [UnboundedStartTime] and [UnboundedEndTime].
+	TimestampRange struct {
+		Start, End int64
+	}
+)
+
+// Has returns true if i is in the set.
+func (x Bitmap) Has(i int) bool {
+	return x[i>>6]&(1<<(i&63)) != 0
+}
+
+// Count returns the number of indexes in the set.
+func (x Bitmap) Count() (n int) {
+	for _, w := range x {
+		n += bits.OnesCount64(w)
+	}
+	return
+}
+
+// AppendIndexes appends the indexes in the set to dst, in ascending order.
+func (x Bitmap) AppendIndexes(dst []int) []int {
+	for i, w := range x {
+		for w != 0 {
+			dst = append(dst, i<<6|bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+	return dst
+}
+
+// resize resizes x to hold n bits, reusing the backing array if possible.
+// The contents are undefined, and must be overwritten, word by word.
+func (x Bitmap) resize(n int) Bitmap {
+	words := (n + 63) >> 6
+	if cap(x) < words {
+		return make(Bitmap, words)
+	}
+	return x[:words]
+}
+
+// ParseDateRange pre-parses a date range, see [DateRange].
+func ParseDateRange(startDate, endDate string) (r DateRange, err error) {
+	r.Start, r.End = UnboundedStartDate, UnboundedEndDate
+	if startDate != `` {
+		if r.Start, err = ParseEpochDate(startDate); err != nil {
+			return
+		}
+	}
+	if endDate != `` {
+		if r.End, err = ParseEpochDate(endDate); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// TimestampRange converts r, equivalent to [ExampleDateToTimestamp].
+func (r DateRange) TimestampRange(u EpochUnit) TimestampRange {
+	start, end := u.DateToTimestamp(r.Start, r.End)
+	return TimestampRange{start, end}
+}
+
+// MatchEpochDates sets bit i of the returned bitmap, if values[i] matches r.
+// The dst bitmap will be reused, if it has sufficient capacity.
+func (r DateRange) MatchEpochDates(dst Bitmap, values []int32) Bitmap {
+	dst = dst.resize(len(values))
+	for i := range dst {
+		var w uint64
+		for j, v := range values[i<<6 : min(i<<6+64, len(values))] {
+			w |= (b2u(v >= r.Start) & b2u(v <= r.End)) << j
+		}
+		dst[i] = w
+	}
+	return dst
+}
+
+// MatchDates is a variant of [DateRange.MatchEpochDates] that accepts date
+// strings, returning an error if any value is invalid.
+func (r DateRange) MatchDates(dst Bitmap, values []string) (Bitmap, error) {
+	dst = dst.resize(len(values))
+	for i := range dst {
+		var w uint64
+		for j, s := range values[i<<6 : min(i<<6+64, len(values))] {
+			v, err := ParseEpochDate(s)
+			if err != nil {
+				return dst, err
+			}
+			w |= (b2u(v >= r.Start) & b2u(v <= r.End)) << j
+		}
+		dst[i] = w
+	}
+	return dst, nil
+}
+
+// NewTimestampRange converts a timestamp range to the given unit, treating
+// the zero value as unset, see [TimestampRange]. Both bounds are rounded up,
+// to a whole unit, such that a value matches iff the time it represents
+// matches the original range, e.g. a start of 00:00:00.0005, in
+// [EpochMillis], is 00:00:00.001. Bounds beyond the range of the unit
+// saturate, per [EpochUnit].
+func NewTimestampRange(u EpochUnit, startTime, endTime time.Time) TimestampRange {
+	r := TimestampRange{UnboundedStartTime, UnboundedEndTime}
+	if startTime != (time.Time{}) {
+		r.Start = epochTime(u, startTime)
+	}
+	if endTime != (time.Time{}) {
+		r.End = epochTime(u, endTime)
+	}
+	return r
+}
+
+// DateRange converts r, equivalent to [ExampleTimestampToDate].
+func (r TimestampRange) DateRange(u EpochUnit) DateRange {
+	start, end := u.TimestampToDate(r.Start, r.End)
+	return DateRange{start, end}
+}
+
+// MatchEpochTimestamps sets bit i of the returned bitmap, if values[i]
+// matches r. The dst bitmap will be reused, if it has sufficient capacity.
+func (r TimestampRange) MatchEpochTimestamps(dst Bitmap, values []int64) Bitmap {
+	dst = dst.resize(len(values))
+	unbounded := b2u(r.End == UnboundedEndTime)
+	for i := range dst {
+		var w uint64
+		for j, v := range values[i<<6 : min(i<<6+64, len(values))] {
+			w |= (b2u(v >= r.Start) & (b2u(v < r.End) | unbounded)) << j
+		}
+		dst[i] = w
+	}
+	return dst
+}
+
+// StartDates is a batch variant of [EpochUnit.StartDate], that appends to
+// dst.
+func (u EpochUnit) StartDates(dst []int32, startTimes []int64) []int32 {
+	for _, v := range startTimes {
+		dst = append(dst, u.StartDate(v))
+	}
+	return dst
+}
+
+// EndDates is a batch variant of [EpochUnit.EndDate], that appends to dst.
+func (u EpochUnit) EndDates(dst []int32, endTimes []int64) []int32 {
+	for _, v := range endTimes {
+		dst = append(dst, u.EndDate(v))
+	}
+	return dst
+}
+
+// StartTimes is a batch variant of [EpochUnit.StartTime], that appends to
+// dst.
+func (u EpochUnit) StartTimes(dst []int64, startDates []int32) []int64 {
+	for _, v := range startDates {
+		dst = append(dst, u.StartTime(v))
+	}
+	return dst
+}
+
+// EndTimes is a batch variant of [EpochUnit.EndTime], that appends to dst.
+func (u EpochUnit) EndTimes(dst []int64, endDates []int32) []int64 {
+	for _, v := range endDates {
+		dst = append(dst, u.EndTime(v))
+	}
+	return dst
+}
+
+// WidenStartTimes is a batch variant of [EpochUnit.WidenStartTime], that
+// appends to dst.
+func (u EpochUnit) WidenStartTimes(dst, startTimes []int64) []int64 {
+	for _, v := range startTimes {
+		dst = append(dst, u.WidenStartTime(v))
+	}
+	return dst
+}
+
+// WidenEndTimes is a batch variant of [EpochUnit.WidenEndTime], that appends
+// to dst.
+func (u EpochUnit) WidenEndTimes(dst, endTimes []int64) []int64 {
+	for _, v := range endTimes {
+		dst = append(dst, u.WidenEndTime(v))
+	}
+	return dst
+}
+
+// epochTime converts t to the unit, rounding up, see [NewTimestampRange].
+func epochTime(u EpochUnit, t time.Time) int64 {
+	var scale int64
+	switch u {
+	case EpochNanos:
+		scale = int64(time.Second / time.Nanosecond)
+	case EpochMillis:
+		scale = int64(time.Second / time.Millisecond)
+	case EpochSeconds:
+		scale = 1
+	default:
+		panic(`unsupported epoch unit`)
+	}
+	sub := ceilDiv(int64(t.Nanosecond()), int64(time.Second)/scale)
+	switch v := saturateMul(t.Unix(), scale); {
+	case v == UnboundedStartTime:
+		return v
+	case v > UnboundedEndTime-sub:
+		return UnboundedEndTime
+	default:
+		return v + sub
+	}
+}
+
+// b2u converts a bool to 0 or 1, which the compiler implements without a
+// branch.
+func b2u(b bool) uint64 {
+	var v uint64
+	if b {
+		v = 1
+	}
+	return v
+}
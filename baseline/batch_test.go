@@ -0,0 +1,225 @@
+package baseline
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDateRange_MatchDates(t *testing.T) {
+	var (
+		dst     Bitmap
+		indexes []int
+	)
+	for _, r := range DateRangeValues {
+		for i := range 3 {
+			r := r
+			if i != 0 {
+				r[i-1] = ``
+			}
+			dr, err := ParseDateRange(r[0], r[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			dst, err = dr.MatchDates(dst, DateValues)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected []int
+			for j, v := range DateValues {
+				if MatchesDate(r[0], r[1], v) {
+					expected = append(expected, j)
+				}
+				if dst.Has(j) != MatchesDate(r[0], r[1], v) {
+					t.Errorf("[%s, %s] matching %s: expected %t", r[0], r[1], v, !dst.Has(j))
+				}
+			}
+			if indexes = dst.AppendIndexes(indexes[:0]); !slices.Equal(indexes, expected) {
+				t.Errorf("[%s, %s]: expected %v, got %v", r[0], r[1], expected, indexes)
+			}
+			if n := dst.Count(); n != len(expected) {
+				t.Errorf("[%s, %s]: expected count %d, got %d", r[0], r[1], len(expected), n)
+			}
+		}
+	}
+}
+
+func TestTimestampRange_MatchEpochTimestamps(t *testing.T) {
+	values := make([]int64, 0, len(TimestampValues))
+	times := make([]time.Time, 0, len(TimestampValues))
+	for _, v := range TimestampValues {
+		ts, err := ParseTimestamp(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		times = append(times, ts)
+		values = append(values, ts.UnixMilli())
+	}
+	var dst Bitmap
+	for _, r := range DateRangeValues {
+		for i := range 3 {
+			r := r
+			if i != 0 {
+				r[i-1] = ``
+			}
+			dr, err := ParseDateRange(r[0], r[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			startTime, endTime := ExampleDateToTimestamp(r[0], r[1])
+			tr := dr.TimestampRange(EpochMillis)
+			if tr != NewTimestampRange(EpochMillis, startTime, endTime) {
+				t.Fatalf("[%s, %s]: %v", r[0], r[1], tr)
+			}
+			dst = tr.MatchEpochTimestamps(dst, values)
+			for j, v := range times {
+				if dst.Has(j) != MatchesTimestamp(startTime, endTime, v) {
+					t.Errorf("[%s, %s] matching %s: expected %t", r[0], r[1], TimestampValues[j], !dst.Has(j))
+				}
+			}
+		}
+	}
+}
+
+func TestNewTimestampRange_subUnit(t *testing.T) {
+	startTime, err := ParseTimestamp("2024-01-01T00:00:00.0005Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	endTime, err := ParseTimestamp("2024-01-02T00:00:00.0005Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range [...]EpochUnit{EpochMillis, EpochSeconds} {
+		tr := NewTimestampRange(u, startTime, endTime)
+		a, b := ExampleTimestampToDate(startTime, endTime)
+		if dr, err := ParseDateRange(a, b); err != nil || tr.DateRange(u) != dr {
+			t.Errorf("%d: expected [%s, %s], got %v", u, a, b, tr.DateRange(u))
+		}
+		var values []int64
+		var times []time.Time
+		for _, v := range [...]string{
+			"2024-01-01T00:00:00Z",
+			"2024-01-01T00:00:00.001Z",
+			"2024-01-01T00:00:01Z",
+			"2024-01-02T00:00:00Z",
+			"2024-01-02T00:00:00.001Z",
+			"2024-01-02T00:00:01Z",
+		} {
+			ts, err := ParseTimestamp(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u == EpochSeconds && ts.Nanosecond() != 0 {
+				continue
+			}
+			times = append(times, ts)
+			values = append(values, epochTime(u, ts))
+		}
+		dst := tr.MatchEpochTimestamps(nil, values)
+		for i, v := range times {
+			if dst.Has(i) != MatchesTimestamp(startTime, endTime, v) {
+				t.Errorf("%d: matching %s: expected %t", u, FormatTimestamp(v), !dst.Has(i))
+			}
+		}
+	}
+}
+
+func TestEpochUnit_batch(t *testing.T) {
+	var times []int64
+	for _, v := range TimestampValues {
+		ts, err := ParseTimestamp(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		times = append(times, ts.Unix())
+	}
+	u := EpochSeconds
+	startDates := u.StartDates(nil, times)
+	endDates := u.EndDates(nil, times)
+	startTimes := u.StartTimes(nil, startDates)
+	endTimes := u.EndTimes(nil, endDates)
+	wideStartTimes := u.WidenStartTimes(nil, times)
+	wideEndTimes := u.WidenEndTimes(nil, times)
+	for i, v := range times {
+		if startDates[i] != u.StartDate(v) || endDates[i] != u.EndDate(v) ||
+			startTimes[i] != u.StartTime(startDates[i]) || endTimes[i] != u.EndTime(endDates[i]) ||
+			wideStartTimes[i] != u.WidenStartTime(v) || wideEndTimes[i] != u.WidenEndTime(v) {
+			t.Errorf("mismatch for %d", v)
+		}
+	}
+}
+
+func benchmarkDateValues(n int) []string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = DateValues[i%len(DateValues)]
+	}
+	return values
+}
+
+func BenchmarkDateRange_MatchDates(b *testing.B) {
+	values := benchmarkDateValues(1024)
+	r, _ := ParseDateRange("2024-03-01", "2024-09-30")
+	var dst Bitmap
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst, _ = r.MatchDates(dst, values)
+	}
+}
+
+func BenchmarkMatchesDate_scalar(b *testing.B) {
+	values := benchmarkDateValues(1024)
+	dst := make([]bool, len(values))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, v := range values {
+			dst[j] = MatchesDate("2024-03-01", "2024-09-30", v)
+		}
+	}
+}
+
+func BenchmarkDateRange_MatchEpochDates(b *testing.B) {
+	values := make([]int32, 1024)
+	for i := range values {
+		values[i] = int32(19700 + i)
+	}
+	r := DateRange{19800, 20000}
+	var dst Bitmap
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst = r.MatchEpochDates(dst, values)
+	}
+}
+
+func BenchmarkMatchesEpochDate_scalar(b *testing.B) {
+	values := make([]int32, 1024)
+	for i := range values {
+		values[i] = int32(19700 + i)
+	}
+	dst := make([]bool, len(values))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, v := range values {
+			dst[j] = MatchesEpochDate(19800, 20000, v)
+		}
+	}
+}
+
+func BenchmarkTimestampRange_MatchEpochTimestamps(b *testing.B) {
+	values := make([]int64, 1024)
+	for i := range values {
+		values[i] = int64(i) * 3600
+	}
+	r := TimestampRange{86400, UnboundedEndTime}
+	var dst Bitmap
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst = r.MatchEpochTimestamps(dst, values)
+	}
+}
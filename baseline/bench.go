@@ -0,0 +1,180 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Latencies summarises the durations of a sequence of calls, e.g. as
+// measured by [MeasureTimestampToDate].
+type Latencies struct {
+	// Count is the number of calls.
+	Count int
+	// Elapsed is the total (wall) time taken by the calls.
+	Elapsed time.Duration
+	// P50, P90 and P99 are percentiles (nearest rank), of the durations.
+	P50, P90, P99 time.Duration
+	// Max is the longest duration.
+	Max time.Duration
+}
+
+// NewLatencies summarises the durations, which it sorts.
+func NewLatencies(durations []time.Duration, elapsed time.Duration) *Latencies {
+	slices.Sort(durations)
+	x := Latencies{Count: len(durations), Elapsed: elapsed}
+	if x.Count == 0 {
+		return &x
+	}
+	percentile := func(p float64) time.Duration {
+		return durations[max(int(math.Ceil(p*float64(x.Count)))-1, 0)]
+	}
+	x.P50, x.P90, x.P99, x.Max = percentile(0.5), percentile(0.9), percentile(0.99), durations[x.Count-1]
+	return &x
+}
+
+// Throughput returns the number of calls per second.
+func (x *Latencies) Throughput() float64 {
+	if x.Elapsed <= 0 {
+		return 0
+	}
+	return float64(x.Count) / x.Elapsed.Seconds()
+}
+
+// Mean returns the mean duration of the calls, i.e. the elapsed time per
+// call. Unlike the percentiles, means may be subtracted, e.g. to exclude an
+// overhead measured separately.
+func (x *Latencies) Mean() time.Duration {
+	if x.Count == 0 {
+		return 0
+	}
+	return x.Elapsed / time.Duration(x.Count)
+}
+
+func (x *Latencies) String() string {
+	return fmt.Sprintf(`%d calls in %s (%.1f/s): p50 %s, p90 %s, p99 %s, max %s`, x.Count, x.Elapsed, x.Throughput(), x.P50, x.P90, x.P99, x.Max)
+}
+
+// BenchmarkTimestampToDate may be used to benchmark a [TimestampToDate]
+// implementation, converting each of the timestamp ranges in turn, including
+// the variants with either bound unset, per [TimestampRangeVariants].
+func BenchmarkTimestampToDate(b *testing.B, ranges [][2]string, convert TimestampToDate) {
+	inputs, err := TimestampRangeVariants(ranges)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		b.Fatal(`no ranges`)
+	}
+	var sink [2]string
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := inputs[i%len(inputs)]
+		sink[0], sink[1] = convert(r[0], r[1])
+	}
+	runtime.KeepAlive(sink)
+}
+
+// BenchmarkDateToTimestamp may be used to benchmark a [DateToTimestamp]
+// implementation, converting each of the date ranges in turn, including the
+// variants with either bound unset, per [RangeTestCases].
+func BenchmarkDateToTimestamp(b *testing.B, ranges [][2]string, convert DateToTimestamp) {
+	inputs, err := dateRangeVariants(ranges)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		b.Fatal(`no ranges`)
+	}
+	var sink [2]time.Time
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := inputs[i%len(inputs)]
+		sink[0], sink[1] = convert(r[0], r[1])
+	}
+	runtime.KeepAlive(sink)
+}
+
+// MeasureTimestampToDate calls convert n times, sequentially, cycling
+// through the timestamp ranges (see [BenchmarkTimestampToDate]), returning
+// the latency of each call. Unlike the benchmark, it is suitable for slow
+// implementations, e.g. external commands. Panics are returned as errors.
+func MeasureTimestampToDate(ctx context.Context, n int, ranges [][2]string, convert TimestampToDate) (*Latencies, error) {
+	inputs, err := TimestampRangeVariants(ranges)
+	if err != nil {
+		return nil, err
+	}
+	var sink [2]string
+	defer runtime.KeepAlive(&sink)
+	return measure(ctx, n, len(inputs), func(i int) {
+		sink[0], sink[1] = convert(inputs[i][0], inputs[i][1])
+	})
+}
+
+// MeasureDateToTimestamp is the [DateToTimestamp] equivalent of
+// [MeasureTimestampToDate].
+func MeasureDateToTimestamp(ctx context.Context, n int, ranges [][2]string, convert DateToTimestamp) (*Latencies, error) {
+	inputs, err := dateRangeVariants(ranges)
+	if err != nil {
+		return nil, err
+	}
+	var sink [2]time.Time
+	defer runtime.KeepAlive(&sink)
+	return measure(ctx, n, len(inputs), func(i int) {
+		sink[0], sink[1] = convert(inputs[i][0], inputs[i][1])
+	})
+}
+
+func measure(ctx context.Context, n, inputs int, call func(i int)) (latencies *Latencies, err error) {
+	if inputs == 0 {
+		return nil, errors.New(`no ranges`)
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf(`panic: %v`, v)
+		}
+	}()
+	durations := make([]time.Duration, 0, n)
+	start := time.Now()
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		t := time.Now()
+		call(i % inputs)
+		durations = append(durations, time.Since(t))
+	}
+	elapsed := time.Since(start)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return NewLatencies(durations, elapsed), nil
+}
+
+// dateRangeVariants validates date ranges, such as [DateRangeValues],
+// adding the variants with either bound unset, per [RangeTestCases],
+// omitting duplicates.
+func dateRangeVariants(ranges [][2]string) ([][2]string, error) {
+	var variants [][2]string
+	seen := make(map[[2]string]struct{})
+	for _, r := range rangeVariants(ranges) {
+		if _, ok := seen[r]; ok || r == ([2]string{}) {
+			continue
+		}
+		seen[r] = struct{}{}
+		for _, s := range r {
+			if s == `` {
+				continue
+			}
+			if err := ValidateDate(s); err != nil {
+				return nil, err
+			}
+		}
+		variants = append(variants, r)
+	}
+	return variants, nil
+}
@@ -0,0 +1,73 @@
+package baseline
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func BenchmarkExampleTimestampToDate_fixtures(b *testing.B) {
+	BenchmarkTimestampToDate(b, TimestampRangeValues, ExampleTimestampToDate)
+}
+
+func BenchmarkExampleDateToTimestamp_fixtures(b *testing.B) {
+	BenchmarkDateToTimestamp(b, DateRangeValues, ExampleDateToTimestamp)
+}
+
+func TestNewLatencies(t *testing.T) {
+	durations := make([]time.Duration, 200)
+	for i := range durations {
+		durations[i] = time.Duration(200-i) * time.Millisecond
+	}
+	x := NewLatencies(durations, time.Second)
+	if x.Count != 200 || x.P50 != 100*time.Millisecond || x.P90 != 180*time.Millisecond || x.P99 != 198*time.Millisecond || x.Max != 200*time.Millisecond {
+		t.Errorf("unexpected latencies: %s", x)
+	}
+	if v := x.Throughput(); v != 200 {
+		t.Errorf("unexpected throughput: %f", v)
+	}
+	if v := x.Mean(); v != 5*time.Millisecond {
+		t.Errorf("unexpected mean: %s", v)
+	}
+	if v := NewLatencies(nil, 0).Mean(); v != 0 {
+		t.Errorf("unexpected empty mean: %s", v)
+	}
+	if s := NewLatencies(nil, 0).String(); s != `0 calls in 0s (0.0/s): p50 0s, p90 0s, p99 0s, max 0s` {
+		t.Errorf("unexpected empty latencies: %s", s)
+	}
+}
+
+func TestMeasureTimestampToDate(t *testing.T) {
+	var calls int
+	x, err := MeasureTimestampToDate(context.Background(), 100, TimestampRangeValues, func(startTime, endTime time.Time) (startDate, endDate string) {
+		calls++
+		return ExampleTimestampToDate(startTime, endTime)
+	})
+	if err != nil || x.Count != 100 || calls != 100 || x.Max < x.P50 {
+		t.Fatalf("unexpected result: %v, %v, %d calls", x, err, calls)
+	}
+
+	if _, err := MeasureTimestampToDate(context.Background(), 100, TimestampRangeValues, func(startTime, endTime time.Time) (startDate, endDate string) {
+		panic(`some error`)
+	}); err == nil || err.Error() != `panic: some error` {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// N.B. safe for concurrent use, see the -race flag
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = MeasureTimestampToDate(context.Background(), 100, TimestampRangeValues, ExampleTimestampToDate)
+		}()
+	}
+	wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := MeasureDateToTimestamp(ctx, 100, DateRangeValues, ExampleDateToTimestamp); err != context.Canceled {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
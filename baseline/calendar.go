@@ -0,0 +1,456 @@
+package baseline
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxBusinessDaySearch bounds the number of days that will be searched, in
+// either direction, when looking for a business day, to avoid looping forever
+// on a calendar without any business days.
+const maxBusinessDaySearch = 366 * 4
+
+type (
+	// Calendar identifies business days, for use with date ranges.
+	// The date passed to IsBusinessDay will always be the start of a day, in
+	// UTC, consistent with how dates are represented by this package.
+	Calendar interface {
+		IsBusinessDay(date time.Time) bool
+	}
+
+	// CalendarFunc adapts a function to a [Calendar].
+	CalendarFunc func(date time.Time) bool
+
+	// Weekend is a set of weekdays that are not business days, implemented
+	// as a bitmask, where bit N corresponds to time.Weekday(N).
+	Weekend uint8
+
+	// ObservedRule determines when a holiday falling on a weekend is
+	// observed.
+	ObservedRule int
+
+	// Holiday is a fixed (annually recurring) holiday, e.g. Christmas Day.
+	Holiday struct {
+		Name     string
+		Month    time.Month
+		Day      int
+		Observed ObservedRule
+	}
+
+	// HolidayCalendar is a [Calendar] with a weekend rule, fixed holidays
+	// (with optional observance), and one-off holidays (specific dates).
+	// The zero value treats every day as a business day. The fields must
+	// not be modified once it is in use, as the observed holidays are
+	// cached, per year. It must not be copied after first use.
+	HolidayCalendar struct {
+		Weekend  Weekend
+		Holidays []Holiday
+		// Dates are specific (non-recurring) holidays, keyed by date.
+		Dates map[string]string
+
+		// years caches observedHolidays, keyed by year
+		years sync.Map
+	}
+)
+
+const (
+	// ObserveNone means the holiday is only the actual date.
+	ObserveNone ObservedRule = iota
+	// ObserveNextWeekday means a holiday falling on the weekend is observed
+	// on the following weekday, e.g. Saturday and Sunday move to Monday.
+	ObserveNextWeekday
+	// ObserveNearestWeekday means a holiday falling on the weekend is
+	// observed on the nearest weekday, i.e. Saturday moves to Friday, and
+	// Sunday moves to Monday.
+	ObserveNearestWeekday
+)
+
+// SaturdaySunday is the most common [Weekend].
+const SaturdaySunday = Weekend(1<<time.Saturday | 1<<time.Sunday)
+
+var _ Calendar = CalendarFunc(nil)
+var _ Calendar = (*HolidayCalendar)(nil)
+
+// IsBusinessDay implements [Calendar].
+func (x CalendarFunc) IsBusinessDay(date time.Time) bool {
+	return x(date)
+}
+
+// Contains returns true if d is part of the weekend.
+func (x Weekend) Contains(d time.Weekday) bool {
+	return x&(1<<d) != 0
+}
+
+// IsBusinessDay implements [Calendar], returning true for any date that is
+// neither a weekend nor a holiday.
+func (x *HolidayCalendar) IsBusinessDay(date time.Time) bool {
+	if x.Weekend.Contains(date.Weekday()) {
+		return false
+	}
+	return x.HolidayName(date) == ``
+}
+
+// HolidayName returns the name of the holiday that falls on, or is observed
+// on, date, or an empty string if there is none. Unnamed holidays are
+// reported as "holiday".
+func (x *HolidayCalendar) HolidayName(date time.Time) string {
+	date = date.UTC()
+	if name, ok := x.Dates[FormatDate(date)]; ok {
+		if name == `` {
+			name = `holiday`
+		}
+		return name
+	}
+	// N.B. observance may shift across a year boundary (e.g. Jan 1st)
+	return x.observedHolidays(date.Year())[date]
+}
+
+// observedHolidays is a cached variant of [HolidayCalendar.newObservedHolidays].
+func (x *HolidayCalendar) observedHolidays(year int) map[time.Time]string {
+	if v, ok := x.years.Load(year); ok {
+		return v.(map[time.Time]string)
+	}
+	v, _ := x.years.LoadOrStore(year, x.newObservedHolidays(year))
+	return v.(map[time.Time]string)
+}
+
+// newObservedHolidays returns the names of the fixed holidays, keyed by the
+// date they are observed, for the years either side of year. Holidays that
+// aren't moved take precedence, then the moved holidays are observed in
+// order, each rolling forward past any day that isn't a business day, e.g.
+// Christmas Day on a Saturday, and Boxing Day on the Sunday, are observed on
+// the Monday and Tuesday, respectively.
+func (x *HolidayCalendar) newObservedHolidays(year int) map[time.Time]string {
+	type moved struct {
+		name string
+		date time.Time
+	}
+	var (
+		holidays = make(map[time.Time]string)
+		pending  []moved
+	)
+	for y := year - 1; y <= year+1; y++ {
+		for _, h := range x.Holidays {
+			d := time.Date(y, h.Month, h.Day, 0, 0, 0, 0, time.UTC)
+			if d.Day() != h.Day {
+				// e.g. Feb 29th, in a non-leap year
+				continue
+			}
+			name := h.Name
+			if name == `` {
+				name = `holiday`
+			}
+			if o := x.observed(h, d); !o.Equal(d) {
+				pending = append(pending, moved{name, o})
+				continue
+			}
+			if _, ok := holidays[d]; !ok {
+				holidays[d] = name
+			}
+		}
+	}
+	slices.SortStableFunc(pending, func(a, b moved) int { return a.date.Compare(b.date) })
+	for _, v := range pending {
+		d := v.date
+		for i := 0; i < maxBusinessDaySearch && !x.isFree(holidays, d); i++ {
+			d = d.Add(oneDay)
+		}
+		holidays[d] = v.name
+	}
+	return holidays
+}
+
+// observed returns the date that h is observed, given the date it falls on.
+func (x *HolidayCalendar) observed(h Holiday, d time.Time) time.Time {
+	if !x.Weekend.Contains(d.Weekday()) {
+		return d
+	}
+	switch h.Observed {
+	case ObserveNextWeekday:
+		for i := 0; i < 7 && x.Weekend.Contains(d.Weekday()); i++ {
+			d = d.Add(oneDay)
+		}
+	case ObserveNearestWeekday:
+		prev, next := d, d
+		for i := 0; i < 7; i++ {
+			next = next.Add(oneDay)
+			if !x.Weekend.Contains(next.Weekday()) {
+				return next
+			}
+			prev = prev.Add(-oneDay)
+			if !x.Weekend.Contains(prev.Weekday()) {
+				return prev
+			}
+		}
+	}
+	return d
+}
+
+// isFree returns true if d is a business day, given the observed holidays.
+func (x *HolidayCalendar) isFree(holidays map[time.Time]string, d time.Time) bool {
+	if _, ok := holidays[d]; ok || x.Weekend.Contains(d.Weekday()) {
+		return false
+	}
+	if len(x.Dates) != 0 {
+		if _, ok := x.Dates[FormatDate(d)]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// validate returns an error if there are more than maxBusinessDaySearch
+// consecutive days that aren't business days, e.g. if every day is part of
+// the weekend, which the business day functions can't handle. The fixed
+// holidays repeat every 400 years, which is a whole number of weeks, so only
+// one such cycle, and the span of the one-off dates, need to be checked.
+func (x *HolidayCalendar) validate() error {
+	if x.Weekend == 1<<7-1 {
+		return errors.New(`calendar has no business days: every day is part of the weekend`)
+	}
+	spans := [][2]time.Time{{
+		time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2400, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, maxBusinessDaySearch),
+	}}
+	dates := make(map[time.Time]struct{}, len(x.Dates))
+	if len(x.Dates) != 0 {
+		var first, last time.Time
+		for date := range x.Dates {
+			d, err := ParseDate(date)
+			if err != nil {
+				return err
+			}
+			dates[d] = struct{}{}
+			if first.IsZero() || d.Before(first) {
+				first = d
+			}
+			if d.After(last) {
+				last = d
+			}
+		}
+		spans = append(spans, [2]time.Time{
+			first.AddDate(0, 0, -maxBusinessDaySearch),
+			last.AddDate(0, 0, maxBusinessDaySearch),
+		})
+	}
+	years := make(map[int]map[time.Time]string)
+	for _, span := range spans {
+		var n int
+		for d := span[0]; !d.After(span[1]); d = d.Add(oneDay) {
+			holidays, ok := years[d.Year()]
+			if !ok {
+				// N.B. not cached, as it may span centuries
+				holidays = x.newObservedHolidays(d.Year())
+				years[d.Year()] = holidays
+			}
+			_, holiday := holidays[d]
+			if _, ok := dates[d]; ok || holiday || x.Weekend.Contains(d.Weekday()) {
+				n++
+			} else {
+				n = 0
+			}
+			if n >= maxBusinessDaySearch {
+				return fmt.Errorf(`calendar has no business days between %s and %s`, FormatDate(d.AddDate(0, 0, 1-n)), FormatDate(d))
+			}
+		}
+	}
+	return nil
+}
+
+// ParseCalendar reads a [HolidayCalendar] from a simple line-based format.
+// Blank lines, and lines starting with #, are ignored. Each other line is a
+// directive, followed by whitespace-separated fields:
+//
+//	weekend Sat Sun
+//	fixed 12-25 Christmas Day
+//	observed 01-01 New Year's Day
+//	nearest 07-04 Independence Day
+//	date 2024-03-29 Good Friday
+//
+// The fixed directive adds a [Holiday] that is never moved, while observed
+// and nearest add holidays using [ObserveNextWeekday] and
+// [ObserveNearestWeekday] respectively. Names are optional. Weekday names may
+// be abbreviated to three letters, and are case-insensitive. Calendars
+// without any business days, for over four years, are rejected.
+func ParseCalendar(r io.Reader) (*HolidayCalendar, error) {
+	cal := HolidayCalendar{Dates: make(map[string]string)}
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == `` || strings.HasPrefix(text, `#`) {
+			continue
+		}
+		fields := strings.Fields(text)
+		if err := cal.parseDirective(fields); err != nil {
+			return nil, fmt.Errorf(`calendar line %d: %w`, line, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if err := cal.validate(); err != nil {
+		return nil, err
+	}
+	return &cal, nil
+}
+
+// LoadCalendar reads a [HolidayCalendar] from a file, see [ParseCalendar].
+func LoadCalendar(name string) (*HolidayCalendar, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseCalendar(f)
+}
+
+func (x *HolidayCalendar) parseDirective(fields []string) error {
+	switch directive := fields[0]; directive {
+	case `weekend`:
+		for _, v := range fields[1:] {
+			d, err := parseWeekday(v)
+			if err != nil {
+				return err
+			}
+			x.Weekend |= 1 << d
+		}
+		return nil
+
+	case `fixed`, `observed`, `nearest`:
+		if len(fields) < 2 {
+			return fmt.Errorf(`%s: missing MM-DD`, directive)
+		}
+		d, err := time.ParseInLocation(`01-02`, fields[1], time.UTC)
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, directive, err)
+		}
+		h := Holiday{
+			Name:  strings.Join(fields[2:], ` `),
+			Month: d.Month(),
+			Day:   d.Day(),
+		}
+		switch directive {
+		case `observed`:
+			h.Observed = ObserveNextWeekday
+		case `nearest`:
+			h.Observed = ObserveNearestWeekday
+		}
+		x.Holidays = append(x.Holidays, h)
+		return nil
+
+	case `date`:
+		if len(fields) < 2 {
+			return fmt.Errorf(`%s: missing date`, directive)
+		}
+		if err := ValidateDate(fields[1]); err != nil {
+			return fmt.Errorf(`%s: %w`, directive, err)
+		}
+		x.Dates[fields[1]] = strings.Join(fields[2:], ` `)
+		return nil
+
+	default:
+		return fmt.Errorf(`unknown directive %q`, directive)
+	}
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if name := d.String(); strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf(`invalid weekday %q`, s)
+}
+
+// BusinessDays calls f for each business day within the inclusive range
+// [startDate, endDate], in order, stopping if f returns false. Both bounds
+// are required.
+func BusinessDays(cal Calendar, startDate, endDate string, f func(date string) bool) {
+	start, end := mustParseDateRange(startDate, endDate)
+	for d := start; !d.After(end); d = d.Add(oneDay) {
+		if cal.IsBusinessDay(d) && !f(FormatDate(d)) {
+			return
+		}
+	}
+}
+
+// CountBusinessDays returns the number of business days within the inclusive
+// range [startDate, endDate]. Both bounds are required.
+func CountBusinessDays(cal Calendar, startDate, endDate string) (n int) {
+	BusinessDays(cal, startDate, endDate, func(string) bool {
+		n++
+		return true
+	})
+	return
+}
+
+// NarrowToBusinessDays moves startDate forward, and endDate backward, to the
+// nearest business day (inclusive), such that the range starts and ends on a
+// business day. Unset (empty) bounds are preserved. Like
+// [ExampleTimestampToDate], the result may be an empty range, where startDate
+// is after endDate.
+func NarrowToBusinessDays(cal Calendar, startDate, endDate string) (string, string) {
+	if startDate != `` {
+		startDate = nearestBusinessDay(cal, startDate, oneDay)
+	}
+	if endDate != `` {
+		endDate = nearestBusinessDay(cal, endDate, -oneDay)
+	}
+	return startDate, endDate
+}
+
+// WidenToBusinessDays moves startDate backward, and endDate forward, to the
+// nearest business day (inclusive), such that the range starts and ends on a
+// business day, and includes all the original dates. Unset (empty) bounds
+// are preserved.
+func WidenToBusinessDays(cal Calendar, startDate, endDate string) (string, string) {
+	if startDate != `` {
+		startDate = nearestBusinessDay(cal, startDate, -oneDay)
+	}
+	if endDate != `` {
+		endDate = nearestBusinessDay(cal, endDate, oneDay)
+	}
+	return startDate, endDate
+}
+
+// MatchesBusinessDate is a variant of [MatchesDate] that only matches values
+// that are business days, per cal.
+func MatchesBusinessDate(cal Calendar, startDate, endDate, value string) bool {
+	if !MatchesDate(startDate, endDate, value) {
+		return false
+	}
+	return cal.IsBusinessDay(mustParseDate(value))
+}
+
+func nearestBusinessDay(cal Calendar, date string, step time.Duration) string {
+	d := mustParseDate(date)
+	for range maxBusinessDaySearch {
+		if cal.IsBusinessDay(d) {
+			return FormatDate(d)
+		}
+		d = d.Add(step)
+	}
+	panic(fmt.Errorf(`no business day within %d days of %s`, maxBusinessDaySearch, date))
+}
+
+func mustParseDateRange(startDate, endDate string) (time.Time, time.Time) {
+	if startDate == `` || endDate == `` {
+		panic(fmt.Errorf(`business day range must be bounded: [%s, %s]`, startDate, endDate))
+	}
+	return mustParseDate(startDate), mustParseDate(endDate)
+}
+
+func mustParseDate(s string) time.Time {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
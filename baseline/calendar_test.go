@@ -0,0 +1,185 @@
+package baseline
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testCalendar = `
+# example trading calendar
+weekend Sat Sun
+observed 01-01 New Year's Day
+nearest 07-04 Independence Day
+observed 12-25 Christmas Day
+date 2024-03-29 Good Friday
+`
+
+func mustTestCalendar(t testing.TB) *HolidayCalendar {
+	cal, err := ParseCalendar(strings.NewReader(testCalendar))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cal
+}
+
+func ExampleBusinessDays() {
+	cal, _ := ParseCalendar(strings.NewReader(testCalendar))
+	BusinessDays(cal, "2024-03-27", "2024-04-02", func(date string) bool {
+		fmt.Println(date)
+		return true
+	})
+	//output:
+	//2024-03-27
+	//2024-03-28
+	//2024-04-01
+	//2024-04-02
+}
+
+func TestHolidayCalendar_HolidayName(t *testing.T) {
+	cal := mustTestCalendar(t)
+	for _, tc := range [...]struct {
+		date string
+		name string
+	}{
+		{"2024-01-01", "New Year's Day"},
+		{"2023-01-02", "New Year's Day"}, // Sunday -> Monday
+		{"2022-01-03", "New Year's Day"}, // Saturday -> Monday
+		{"2021-12-31", ""},               // not observed on the Friday
+		{"2026-07-03", "Independence Day"},
+		{"2021-07-05", "Independence Day"},
+		{"2024-03-29", "Good Friday"},
+		{"2022-12-26", "Christmas Day"},
+		{"2024-12-26", ""},
+	} {
+		t.Run(tc.date, func(t *testing.T) {
+			if name := cal.HolidayName(mustParseDate(tc.date)); name != tc.name {
+				t.Errorf("expected %q, got %q", tc.name, name)
+			}
+		})
+	}
+}
+
+func TestHolidayCalendar_HolidayName_collisions(t *testing.T) {
+	cal, err := ParseCalendar(strings.NewReader("weekend Sat Sun\nobserved 12-25 Christmas Day\nobserved 12-26 Boxing Day\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range [...]struct {
+		date string
+		name string
+	}{
+		// Saturday and Sunday
+		{"2021-12-27", "Christmas Day"},
+		{"2021-12-28", "Boxing Day"},
+		// Sunday and Monday, the latter taking precedence
+		{"2022-12-26", "Boxing Day"},
+		{"2022-12-27", "Christmas Day"},
+		{"2022-12-28", ""},
+	} {
+		if name := cal.HolidayName(mustParseDate(tc.date)); name != tc.name {
+			t.Errorf("%s: expected %q, got %q", tc.date, tc.name, name)
+		}
+	}
+}
+
+func TestHolidayCalendar_IsBusinessDay_cached(t *testing.T) {
+	cal := mustTestCalendar(t)
+	date := mustParseDate("2024-07-04")
+	// N.B. safe for concurrent use, see the -race flag
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if cal.IsBusinessDay(date) {
+				t.Error("expected holiday")
+			}
+		}()
+	}
+	wg.Wait()
+	// the observed holidays are only computed once, per year
+	if n := testing.AllocsPerRun(100, func() { cal.IsBusinessDay(date) }); n > 2 {
+		t.Errorf("unexpected allocations: %v", n)
+	}
+}
+
+func TestNarrowToBusinessDays(t *testing.T) {
+	cal := mustTestCalendar(t)
+	for _, tc := range [...][4]string{
+		{"2024-03-29", "2024-04-07", "2024-04-01", "2024-04-05"},
+		{"2024-03-30", "2024-03-31", "2024-04-01", "2024-03-28"}, // empty
+		{"", "2024-12-25", "", "2024-12-24"},
+		{"2024-12-25", "", "2024-12-26", ""},
+	} {
+		if s, e := NarrowToBusinessDays(cal, tc[0], tc[1]); s != tc[2] || e != tc[3] {
+			t.Errorf("[%s, %s]: expected [%s, %s], got [%s, %s]", tc[0], tc[1], tc[2], tc[3], s, e)
+		}
+	}
+}
+
+func TestWidenToBusinessDays(t *testing.T) {
+	cal := mustTestCalendar(t)
+	for _, tc := range [...][4]string{
+		{"2024-03-29", "2024-04-07", "2024-03-28", "2024-04-08"},
+		{"2024-04-01", "2024-04-05", "2024-04-01", "2024-04-05"},
+		{"", "2024-12-25", "", "2024-12-26"},
+	} {
+		if s, e := WidenToBusinessDays(cal, tc[0], tc[1]); s != tc[2] || e != tc[3] {
+			t.Errorf("[%s, %s]: expected [%s, %s], got [%s, %s]", tc[0], tc[1], tc[2], tc[3], s, e)
+		}
+	}
+}
+
+func TestCountBusinessDays(t *testing.T) {
+	cal := mustTestCalendar(t)
+	// 2024 has 262 weekdays, less New Year's Day, Independence Day, Good Friday, and Christmas Day
+	if n := CountBusinessDays(cal, "2024-01-01", "2024-12-31"); n != 258 {
+		t.Error(n)
+	}
+	if n := CountBusinessDays(cal, "2024-01-02", "2024-01-01"); n != 0 {
+		t.Error(n)
+	}
+}
+
+func TestMatchesBusinessDate(t *testing.T) {
+	cal := mustTestCalendar(t)
+	if !MatchesBusinessDate(cal, "2024-03-01", "2024-03-31", "2024-03-28") {
+		t.Error("expected match")
+	}
+	if MatchesBusinessDate(cal, "2024-03-01", "2024-03-31", "2024-03-29") {
+		t.Error("expected holiday to not match")
+	}
+	if MatchesBusinessDate(cal, "2024-03-01", "2024-03-31", "2024-03-30") {
+		t.Error("expected weekend to not match")
+	}
+	if MatchesBusinessDate(cal, "2024-03-01", "2024-03-31", "2024-04-01") {
+		t.Error("expected out of range to not match")
+	}
+}
+
+func TestParseCalendar_errors(t *testing.T) {
+	for _, tc := range [...]string{
+		"weekend Funday",
+		"fixed 13-01",
+		"date 2024-02-30",
+		"unknown 01-01",
+		"observed",
+		"weekend Mon Tue Wed Thu Fri Sat Sun",
+	} {
+		if _, err := ParseCalendar(strings.NewReader(tc)); err == nil {
+			t.Errorf("%q: expected error", tc)
+		}
+	}
+
+	// only Sundays, all of which are holidays, for over four years
+	var b strings.Builder
+	b.WriteString("weekend Mon Tue Wed Thu Fri Sat\n")
+	for d := mustParseDate("2024-01-07"); d.Year() < 2029; d = d.AddDate(0, 0, 7) {
+		b.WriteString("date " + FormatDate(d) + "\n")
+	}
+	if _, err := ParseCalendar(strings.NewReader(b.String())); err == nil || err.Error() != "calendar has no business days between 2024-01-01 and 2028-01-03" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+)
+
+// maxContiguityDays bounds the total span of a [ContiguityCase], to keep
+// checks cheap.
+const maxContiguityDays = 366 * 10
+
+// ContiguityCase is a sequence of Count adjacent timestamp ranges, where
+// range i is [Start + i*Width, Start + (i+1)*Width). Each bound is expressed
+// in a fixed zone, cycling through Offsets (seconds east of UTC), or in the
+// zone of Start, if there are no Offsets. Per the README, converting such a
+// sequence to dates must never select any date more than once, and, if the
+// sequence is aligned (see [ContiguityCase.Aligned]), must select every
+// date, within the sequence, exactly once.
+type ContiguityCase struct {
+	Start   time.Time
+	Width   time.Duration
+	Count   int
+	Offsets []int
+}
+
+// Ranges returns the adjacent timestamp ranges of c.
+func (c ContiguityCase) Ranges() [][2]time.Time {
+	ranges := make([][2]time.Time, c.Count)
+	var offset int
+	bound := func(i int) time.Time {
+		t := c.Start.Add(time.Duration(i) * c.Width)
+		if len(c.Offsets) != 0 {
+			t = t.In(time.FixedZone(``, c.Offsets[offset%len(c.Offsets)]))
+			offset++
+		}
+		return t
+	}
+	for i := range ranges {
+		ranges[i] = [2]time.Time{bound(i), bound(i + 1)}
+	}
+	return ranges
+}
+
+// Aligned returns true if every bound of c is the start of a UTC day.
+func (c ContiguityCase) Aligned() bool {
+	return c.Width%oneDay == 0 && WidenStartTime(c.Start).Equal(c.Start)
+}
+
+// String returns a short, human-readable description of c.
+func (c ContiguityCase) String() string {
+	return fmt.Sprintf(`%s+%sx%d@%v`, FormatTimestamp(c.Start), c.Width, c.Count, c.Offsets)
+}
+
+// Validate checks that c is supported by [CheckContiguity].
+func (c ContiguityCase) Validate() error {
+	if c.Start == (time.Time{}) {
+		return errors.New(`contiguity: start must be set`)
+	}
+	if c.Width <= 0 || c.Count <= 0 {
+		return fmt.Errorf(`contiguity: width (%s) and count (%d) must be positive`, c.Width, c.Count)
+	}
+	if c.Width > maxContiguityDays*oneDay || c.Count > int(maxContiguityDays*oneDay/c.Width) {
+		return fmt.Errorf(`contiguity: sequence exceeds %d days`, maxContiguityDays)
+	}
+	return nil
+}
+
+// CheckContiguity converts each range of c, returning an error describing
+// every way in which the resulting date ranges are not contiguous.
+func CheckContiguity(c ContiguityCase, convert TimestampToDate) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	ranges := c.Ranges()
+	first := WidenStartTime(ranges[0][0].UTC())
+	last := WidenEndTime(ranges[len(ranges)-1][1].UTC())
+
+	var errs []error
+	selectedBy := make(map[string]int)
+	for i, r := range ranges {
+		startDate, endDate := convert(r[0], r[1])
+		start, err := ParseDate(startDate)
+		if err == nil && FormatDate(start) != startDate {
+			err = errors.New(`date format mismatch`)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf(`range %d [%s, %s): startDate %q: %w`, i, FormatTimestamp(r[0]), FormatTimestamp(r[1]), startDate, err))
+			continue
+		}
+		end, err := ParseDate(endDate)
+		if err == nil && FormatDate(end) != endDate {
+			err = errors.New(`date format mismatch`)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf(`range %d [%s, %s): endDate %q: %w`, i, FormatTimestamp(r[0]), FormatTimestamp(r[1]), endDate, err))
+			continue
+		}
+		if start.After(end) {
+			// selects nothing
+			continue
+		}
+		if start.Before(first) || !end.Before(last) {
+			errs = append(errs, fmt.Errorf(`range %d [%s, %s): date range [%s, %s] exceeds the sequence [%s, %s)`, i, FormatTimestamp(r[0]), FormatTimestamp(r[1]), startDate, endDate, FormatDate(first), FormatDate(last)))
+			continue
+		}
+		for d := start; !d.After(end); d = d.Add(oneDay) {
+			date := FormatDate(d)
+			if j, ok := selectedBy[date]; ok {
+				errs = append(errs, fmt.Errorf(`date %s selected by both range %d [%s, %s) and range %d [%s, %s)`, date, j, FormatTimestamp(ranges[j][0]), FormatTimestamp(ranges[j][1]), i, FormatTimestamp(r[0]), FormatTimestamp(r[1])))
+				continue
+			}
+			selectedBy[date] = i
+		}
+	}
+
+	if len(errs) == 0 && c.Aligned() {
+		var missing []string
+		for d := first; d.Before(last); d = d.Add(oneDay) {
+			if _, ok := selectedBy[FormatDate(d)]; !ok {
+				missing = append(missing, FormatDate(d))
+			}
+		}
+		if len(missing) != 0 {
+			errs = append(errs, fmt.Errorf(`aligned sequence did not select date(s): %s`, strings.Join(missing, `, `)))
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf(`contiguity %s: %w`, c, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ContiguityCases generates n pseudo-random cases, deterministically, from
+// the given seed. Approximately half of the cases will be aligned.
+func ContiguityCases(seed uint64, n int) []ContiguityCase {
+	r := rand.New(rand.NewPCG(seed, seed))
+	base := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	randomOffset := func() int {
+		// whole minutes, within the range of real offsets
+		return (r.IntN(26*60+1) - 12*60) * 60
+	}
+	cases := make([]ContiguityCase, n)
+	for i := range cases {
+		c := &cases[i]
+		c.Start = base.Add(time.Duration(r.Int64N(int64(40 * 365 * oneDay))))
+		switch r.IntN(3) {
+		case 0:
+			c.Start = c.Start.Truncate(time.Hour)
+		case 1:
+			c.Start = c.Start.Truncate(time.Minute)
+		}
+		if r.IntN(2) == 0 {
+			c.Start = WidenStartTime(c.Start)
+			c.Width = time.Duration(1+r.IntN(7)) * oneDay
+		} else {
+			c.Width = time.Hour + time.Duration(r.Int64N(int64(10*oneDay)))
+		}
+		c.Count = 2 + r.IntN(30)
+		for range r.IntN(4) {
+			c.Offsets = append(c.Offsets, randomOffset())
+		}
+	}
+	return cases
+}
+
+// TestContiguity may be used to test a [TimestampToDate] implementation,
+// using [CheckContiguity], e.g. with cases from [ContiguityCases].
+func TestContiguity(t *testing.T, cases []ContiguityCase, convert TimestampToDate) {
+	for _, c := range cases {
+		t.Run(c.String(), func(t *testing.T) {
+			if err := CheckContiguity(c, convert); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestContiguityExternal is a variant of [TestContiguity] that does not
+// require a testing.T instance, returning the combined errors.
+func TestContiguityExternal(ctx context.Context, cases []ContiguityCase, convert TimestampToDate) error {
+	var errs []error
+	for _, c := range cases {
+		if err := context.Cause(ctx); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := CheckContiguity(c, convert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FuzzContiguity may be used to fuzz test a [TimestampToDate]
+// implementation, using [CheckContiguity].
+func FuzzContiguity(f *testing.F, convert TimestampToDate) {
+	for _, c := range ContiguityCases(1, 32) {
+		var offsets [2]int
+		for i := range min(len(c.Offsets), 2) {
+			offsets[i] = c.Offsets[i]
+		}
+		f.Add(c.Start.UnixNano(), int64(c.Width), uint8(c.Count), encodeFuzzOffset(offsets[0]), encodeFuzzOffset(offsets[1]), c.Aligned())
+	}
+	f.Fuzz(func(t *testing.T, startEpoch, width int64, count uint8, startOffset, endOffset int, aligned bool) {
+		c := ContiguityCase{
+			Start:   time.Unix(0, startEpoch),
+			Width:   time.Duration(width) % (30 * oneDay),
+			Count:   1 + int(count%64),
+			Offsets: []int{decodeFuzzOffset(startOffset), decodeFuzzOffset(endOffset)},
+		}
+		if c.Width < 0 {
+			c.Width = -c.Width
+		}
+		if c.Width == 0 {
+			c.Width = oneDay
+		}
+		if aligned {
+			c.Start = WidenStartTime(c.Start)
+			c.Width = WidenEndTime(time.Unix(0, 0).Add(c.Width)).Sub(time.Unix(0, 0))
+		}
+		if err := CheckContiguity(c, convert); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
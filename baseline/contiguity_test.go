@@ -0,0 +1,50 @@
+package baseline
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExampleTimestampToDate_contiguity(t *testing.T) {
+	TestContiguity(t, ContiguityCases(1, 200), ExampleTimestampToDate)
+}
+
+func TestTestContiguityExternal_yoDawg(t *testing.T) {
+	if err := TestContiguityExternal(context.Background(), ContiguityCases(2, 50), ExampleTimestampToDate); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckContiguity_local(t *testing.T) {
+	// the naive "local date" implementation, per the README
+	local := func(startTime, endTime time.Time) (startDate, endDate string) {
+		if startTime != (time.Time{}) {
+			startDate = FormatDate(startTime)
+		}
+		if endTime != (time.Time{}) {
+			endDate = FormatDate(endTime.Add(-oneDay))
+		}
+		return
+	}
+	c := ContiguityCase{
+		Start:   time.Date(2024, 7, 16, 0, 0, 0, 0, time.UTC),
+		Width:   oneDay,
+		Count:   3,
+		Offsets: []int{-36000, 36000}, // start, end
+	}
+	err := CheckContiguity(c, local)
+	if err == nil || !strings.Contains(err.Error(), `selected by both range`) {
+		t.Fatal(err)
+	}
+	c.Offsets = []int{36000, -36000}
+	err = CheckContiguity(c, local)
+	if err == nil || !strings.Contains(err.Error(), `did not select`) {
+		t.Fatal(err)
+	}
+}
+
+func FuzzExampleTimestampToDate_contiguity(f *testing.F) {
+	FuzzContiguity(f, ExampleTimestampToDate)
+}
@@ -0,0 +1,196 @@
+package baseline
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+)
+
+type (
+	// NamedTimestampToDate is an implementation, for differential testing,
+	// see [DiffTimestampToDate].
+	NamedTimestampToDate struct {
+		Name    string
+		Convert TimestampToDate
+	}
+
+	// DivergenceResult is the output of a single implementation.
+	DivergenceResult struct {
+		Name               string
+		StartDate, EndDate string
+		// Err is set if the implementation panicked.
+		Err error
+	}
+
+	// Divergence describes a timestamp range, for which the implementations
+	// produced different results.
+	Divergence struct {
+		StartTime, EndTime time.Time
+		// Results are per implementation, in order.
+		Results []DivergenceResult
+		// Reference is the result of the reference implementation, if any,
+		// which may be used to break ties.
+		Reference *DivergenceResult
+	}
+)
+
+func (x DivergenceResult) String() string {
+	if x.Err != nil {
+		return fmt.Sprintf(`%s: %v`, x.Name, x.Err)
+	}
+	return fmt.Sprintf(`%s: [%s, %s]`, x.Name, x.StartDate, x.EndDate)
+}
+
+func (x DivergenceResult) agrees(other DivergenceResult) bool {
+	return x.Err == nil && other.Err == nil && x.StartDate == other.StartDate && x.EndDate == other.EndDate
+}
+
+// Agrees returns the names of the implementations that agree with the
+// reference, or nil if there is no reference.
+func (x *Divergence) Agrees() (names []string) {
+	if x.Reference == nil {
+		return nil
+	}
+	for _, v := range x.Results {
+		if v.agrees(*x.Reference) {
+			names = append(names, v.Name)
+		}
+	}
+	return
+}
+
+func (x *Divergence) String() string {
+	parts := make([]string, 0, len(x.Results)+1)
+	for _, v := range x.Results {
+		parts = append(parts, v.String())
+	}
+	if x.Reference != nil {
+		agrees := x.Agrees()
+		if len(agrees) == 0 {
+			agrees = []string{`none`}
+		}
+		parts = append(parts, fmt.Sprintf(`%s (agrees: %s)`, x.Reference, strings.Join(agrees, `, `)))
+	}
+	return fmt.Sprintf(`[%s, %s): %s`, formatBound(x.StartTime), formatBound(x.EndTime), strings.Join(parts, `; `))
+}
+
+// DiffTimestampToDate converts the range using each implementation, and
+// the reference, if non-nil, returning nil if every implementation agrees
+// (the reference is only used to break ties). An implementation that
+// panics is treated as disagreeing.
+func DiffTimestampToDate(startTime, endTime time.Time, reference TimestampToDate, impls ...NamedTimestampToDate) *Divergence {
+	run := func(name string, convert TimestampToDate) (result DivergenceResult) {
+		result.Name = name
+		result.StartDate, result.EndDate, result.Err = convertTimestampToDateCase(TimestampToDateCase{StartTime: startTime, EndTime: endTime}, convert)
+		return
+	}
+	divergence := Divergence{StartTime: startTime, EndTime: endTime}
+	agree := true
+	for _, v := range impls {
+		result := run(v.Name, v.Convert)
+		if result.Err != nil || (len(divergence.Results) != 0 && !result.agrees(divergence.Results[0])) {
+			agree = false
+		}
+		divergence.Results = append(divergence.Results, result)
+	}
+	if agree {
+		return nil
+	}
+	if reference != nil {
+		result := run(`reference`, reference)
+		divergence.Reference = &result
+	}
+	return &divergence
+}
+
+// DiffTimestampToDateRanges calls [DiffTimestampToDate] for each range,
+// returning every divergence, in order, and the number of ranges compared,
+// which may be less than len(ranges), if ctx is done, in which case the
+// cause is returned as err.
+func DiffTimestampToDateRanges(ctx context.Context, ranges [][2]time.Time, reference TimestampToDate, impls ...NamedTimestampToDate) (divergences []*Divergence, compared int, err error) {
+	for _, r := range ranges {
+		if err = context.Cause(ctx); err != nil {
+			return
+		}
+		if v := DiffTimestampToDate(r[0], r[1], reference, impls...); v != nil {
+			divergences = append(divergences, v)
+		}
+		compared++
+	}
+	return
+}
+
+// TimestampRangeVariants parses timestamp ranges, such as
+// [TimestampRangeValues], adding the variants with either bound unset, per
+// [RangeTestCases], omitting duplicates.
+func TimestampRangeVariants(ranges [][2]string) ([][2]time.Time, error) {
+	var variants [][2]string
+	seen := make(map[[2]string]struct{})
+	for _, r := range rangeVariants(ranges) {
+		if _, ok := seen[r]; ok || r == ([2]string{}) {
+			continue
+		}
+		seen[r] = struct{}{}
+		variants = append(variants, r)
+	}
+	return parseTimestampRanges(variants)
+}
+
+// RandomTimestampRanges returns n pseudo-random timestamp ranges, which are
+// deterministic for a given seed. The ranges may have either bound unset,
+// and the bounds have arbitrary offsets (in whole minutes), precision down
+// to the nanosecond, and spans from under a day to several years.
+func RandomTimestampRanges(seed uint64, n int) [][2]time.Time {
+	r := rand.New(rand.NewPCG(seed, seed))
+	base := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	randomTime := func(t time.Time) time.Time {
+		switch r.IntN(4) {
+		case 0:
+			t = WidenStartTime(t)
+		case 1:
+			t = t.Truncate(time.Hour)
+		case 2:
+			t = t.Truncate(time.Second)
+		}
+		// whole minutes, within the range of real offsets
+		return t.In(time.FixedZone(``, (r.IntN(26*60+1)-12*60)*60))
+	}
+	ranges := make([][2]time.Time, n)
+	for i := range ranges {
+		start := base.Add(time.Duration(r.Int64N(int64(60 * 365 * oneDay))))
+		var span time.Duration
+		switch r.IntN(3) {
+		case 0:
+			span = time.Duration(r.Int64N(int64(2 * oneDay)))
+		case 1:
+			span = time.Duration(r.Int64N(int64(60 * oneDay)))
+		default:
+			span = time.Duration(r.Int64N(int64(5 * 365 * oneDay)))
+		}
+		ranges[i] = [2]time.Time{randomTime(start), randomTime(start.Add(span))}
+		switch r.IntN(8) {
+		case 0:
+			ranges[i][0] = time.Time{}
+		case 1:
+			ranges[i][1] = time.Time{}
+		}
+	}
+	return ranges
+}
+
+// FuzzTimestampToDateDifferential may be used to fuzz test multiple
+// [TimestampToDate] implementations against each other, failing on any
+// [Divergence]. The reference is optional. It shares the seeds and the
+// corpus format of [FuzzTimestampToDate], though the value is unused.
+func FuzzTimestampToDateDifferential(f *testing.F, ranges [][2]string, values []string, reference TimestampToDate, impls ...NamedTimestampToDate) {
+	addTimestampToDateSeeds(f, ranges, values)
+	f.Fuzz(func(t *testing.T, startTimeEpoch int64, startTimeOffset int, endTimeEpoch int64, endTimeOffset int, valueEpoch int64, ignoreStart, ignoreEnd bool) {
+		c := timestampToDateFuzzInput(t, startTimeEpoch, startTimeOffset, endTimeEpoch, endTimeOffset, valueEpoch, ignoreStart, ignoreEnd)
+		if v := DiffTimestampToDate(c.StartTime, c.EndTime, reference, impls...); v != nil {
+			t.Fatal(v)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package baseline
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// alternativeTimestampToDate is an independent implementation, using the
+// widen functions.
+func alternativeTimestampToDate(startTime, endTime time.Time) (startDate, endDate string) {
+	if startTime != (time.Time{}) {
+		startDate = FormatDate(WidenEndTime(startTime).UTC())
+	}
+	if endTime != (time.Time{}) {
+		endDate = FormatDate(WidenStartTime(endTime).UTC().AddDate(0, 0, -1))
+	}
+	return
+}
+
+func inclusiveEndTimestampToDate(startTime, endTime time.Time) (startDate, endDate string) {
+	startDate, endDate = ExampleTimestampToDate(startTime, endTime)
+	if endTime != (time.Time{}) {
+		endDate = FormatDate(endTime.UTC())
+	}
+	return
+}
+
+func TestDiffTimestampToDateRanges_agree(t *testing.T) {
+	ranges, err := TimestampRangeVariants(TimestampRangeValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranges) != 3*len(TimestampRangeValues) {
+		t.Fatalf("unexpected number of ranges: %d", len(ranges))
+	}
+	ranges = append(ranges, RandomTimestampRanges(1, 1000)...)
+	divergences, compared, err := DiffTimestampToDateRanges(context.Background(), ranges, nil,
+		NamedTimestampToDate{`example`, ExampleTimestampToDate},
+		NamedTimestampToDate{`alternative`, alternativeTimestampToDate},
+	)
+	if err != nil || compared != len(ranges) || len(divergences) != 0 {
+		t.Fatalf("unexpected result: compared=%d err=%v divergences=%v", compared, err, divergences)
+	}
+}
+
+func TestDiffTimestampToDate_reference(t *testing.T) {
+	bounds := mustParseTimestamps(t, `2024-01-01T00:00:00Z`, `2024-01-31T23:59:59-08:00`)
+	divergence := DiffTimestampToDate(bounds[0], bounds[1], ExampleTimestampToDate,
+		NamedTimestampToDate{`a`, inclusiveEndTimestampToDate},
+		NamedTimestampToDate{`b`, alternativeTimestampToDate},
+		NamedTimestampToDate{`c`, func(startTime, endTime time.Time) (string, string) { panic(`some panic`) }},
+	)
+	if divergence == nil {
+		t.Fatal(`expected divergence`)
+	}
+	if v := divergence.Agrees(); !slices.Equal(v, []string{`b`}) {
+		t.Errorf("unexpected agrees: %v", v)
+	}
+	if s := divergence.String(); s != `[2024-01-01T00:00:00Z, 2024-01-31T23:59:59-08:00): a: [2024-01-01, 2024-02-01]; b: [2024-01-01, 2024-01-31]; c: panic: some panic; reference: [2024-01-01, 2024-01-31] (agrees: b)` {
+		t.Errorf("unexpected string: %s", s)
+	}
+	if divergence := DiffTimestampToDate(bounds[0], bounds[1], nil,
+		NamedTimestampToDate{`a`, inclusiveEndTimestampToDate},
+		NamedTimestampToDate{`b`, alternativeTimestampToDate},
+	); divergence == nil || divergence.Reference != nil || divergence.Agrees() != nil || strings.Contains(divergence.String(), `reference`) {
+		t.Errorf("unexpected divergence: %v", divergence)
+	}
+}
+
+func TestDiffTimestampToDateRanges_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int
+	divergences, compared, err := DiffTimestampToDateRanges(ctx, RandomTimestampRanges(2, 100), nil,
+		NamedTimestampToDate{`a`, func(startTime, endTime time.Time) (string, string) {
+			if calls++; calls == 10 {
+				cancel()
+			}
+			return inclusiveEndTimestampToDate(startTime, endTime)
+		}},
+		NamedTimestampToDate{`b`, ExampleTimestampToDate},
+	)
+	if err != context.Canceled || compared != 10 || len(divergences) == 0 || len(divergences) > 10 {
+		t.Fatalf("unexpected result: compared=%d err=%v divergences=%d", compared, err, len(divergences))
+	}
+}
+
+func TestRandomTimestampRanges(t *testing.T) {
+	ranges := RandomTimestampRanges(3, 1000)
+	if !slices.EqualFunc(ranges, RandomTimestampRanges(3, 1000), func(a, b [2]time.Time) bool {
+		return formatBound(a[0]) == formatBound(b[0]) && formatBound(a[1]) == formatBound(b[1])
+	}) {
+		t.Fatal(`expected deterministic ranges`)
+	}
+	var unsetStart, unsetEnd, short, precise, offset int
+	for _, r := range ranges {
+		switch {
+		case r[0] == (time.Time{}):
+			unsetStart++
+		case r[1] == (time.Time{}):
+			unsetEnd++
+		case r[1].Sub(r[0]) < oneDay:
+			short++
+		}
+		if r[0].Nanosecond() != 0 {
+			precise++
+		}
+		if _, v := r[1].Zone(); v != 0 {
+			offset++
+		}
+	}
+	for _, v := range [...]int{unsetStart, unsetEnd, short, precise, offset} {
+		if v < 10 {
+			t.Fatalf("unexpected distribution: %d %d %d %d %d", unsetStart, unsetEnd, short, precise, offset)
+		}
+	}
+}
+
+func FuzzTimestampToDateDifferential_alternative(f *testing.F) {
+	FuzzTimestampToDateDifferential(f, TimestampRangeValues, DateValues[:1], ExampleTimestampToDate,
+		NamedTimestampToDate{`example`, ExampleTimestampToDate},
+		NamedTimestampToDate{`alternative`, alternativeTimestampToDate},
+	)
+}
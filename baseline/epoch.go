@@ -0,0 +1,175 @@
+package baseline
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// EpochUnit is the resolution of an integer (unix epoch) timestamp, e.g. as
+// stored by a columnar format, and is the number of such units per day.
+// Dates are represented as the number of days since 1970-01-01 (UTC), as an
+// int32.
+//
+// The methods of EpochUnit are integer-native variants of the functions of
+// this package, that agree exactly with their [time.Time] and string
+// counterparts, and do not allocate. Unset (default) bounds are represented
+// by the UnboundedStart* and UnboundedEnd* constants, which are preserved by
+// conversions.
+//
+// Dates cover approximately ±5.8 million years, as do timestamps in
+// EpochSeconds and EpochMillis, but EpochNanos timestamps cover only
+// approximately ±292 years, i.e. 1677-09-21 to 2262-04-11. Results beyond the
+// range of their type saturate, to the UnboundedStart* (minimum) or
+// UnboundedEnd* (maximum) constants, rather than overflowing, e.g. the
+// StartTime of 2300-01-01, in EpochNanos, is UnboundedEndTime.
+type EpochUnit int64
+
+const (
+	EpochSeconds = EpochUnit(oneDay / time.Second)
+	EpochMillis  = EpochUnit(oneDay / time.Millisecond)
+	EpochNanos   = EpochUnit(oneDay / time.Nanosecond)
+)
+
+const (
+	// UnboundedStartDate represents an unset startDate, for epoch dates.
+	UnboundedStartDate int32 = math.MinInt32
+	// UnboundedEndDate represents an unset endDate, for epoch dates.
+	UnboundedEndDate int32 = math.MaxInt32
+	// UnboundedStartTime represents an unset startTime, for epoch timestamps.
+	UnboundedStartTime int64 = math.MinInt64
+	// UnboundedEndTime represents an unset endTime, for epoch timestamps.
+	UnboundedEndTime int64 = math.MaxInt64
+)
+
+// TimestampToDate is equivalent to [ExampleTimestampToDate].
+func (u EpochUnit) TimestampToDate(startTime, endTime int64) (startDate, endDate int32) {
+	return u.StartDate(startTime), u.EndDate(endTime)
+}
+
+// StartDate converts the startTime of a range, per [ExampleTimestampToDate],
+// i.e. rounding up to the first whole day.
+func (u EpochUnit) StartDate(startTime int64) int32 {
+	if startTime == UnboundedStartTime {
+		return UnboundedStartDate
+	}
+	return saturateDate(ceilDiv(startTime, int64(u)))
+}
+
+// EndDate converts the (exclusive) endTime of a range, per
+// [ExampleTimestampToDate], i.e. the last whole day (inclusive).
+func (u EpochUnit) EndDate(endTime int64) int32 {
+	if endTime == UnboundedEndTime {
+		return UnboundedEndDate
+	}
+	return saturateDate(floorDiv(endTime, int64(u)) - 1)
+}
+
+// WidenRange is equivalent to [WidenRange].
+func (u EpochUnit) WidenRange(startTime, endTime int64) (int64, int64) {
+	return u.WidenStartTime(startTime), u.WidenEndTime(endTime)
+}
+
+// WidenStartTime is equivalent to [WidenStartTime].
+func (u EpochUnit) WidenStartTime(t int64) int64 {
+	if t == UnboundedStartTime {
+		return t
+	}
+	return saturateMul(floorDiv(t, int64(u)), int64(u))
+}
+
+// WidenEndTime is equivalent to [WidenEndTime].
+func (u EpochUnit) WidenEndTime(t int64) int64 {
+	if t == UnboundedEndTime {
+		return t
+	}
+	return saturateMul(ceilDiv(t, int64(u)), int64(u))
+}
+
+// DateToTimestamp is equivalent to [ExampleDateToTimestamp].
+func (u EpochUnit) DateToTimestamp(startDate, endDate int32) (startTime, endTime int64) {
+	return u.StartTime(startDate), u.EndTime(endDate)
+}
+
+// StartTime converts the startDate of a range, per [ExampleDateToTimestamp].
+func (u EpochUnit) StartTime(startDate int32) int64 {
+	if startDate == UnboundedStartDate {
+		return UnboundedStartTime
+	}
+	return saturateMul(int64(startDate), int64(u))
+}
+
+// EndTime converts the (inclusive) endDate of a range, per
+// [ExampleDateToTimestamp], i.e. the start of the following day.
+func (u EpochUnit) EndTime(endDate int32) int64 {
+	if endDate == UnboundedEndDate {
+		return UnboundedEndTime
+	}
+	return saturateMul(int64(endDate)+1, int64(u))
+}
+
+// MatchesEpochDate is equivalent to [MatchesDate].
+func MatchesEpochDate(startDate, endDate, value int32) bool {
+	// N.B. the unbounded values are the extremes, so need no special handling
+	return value >= startDate && value <= endDate
+}
+
+// MatchesEpochTimestamp is equivalent to [MatchesTimestamp].
+func MatchesEpochTimestamp(startTime, endTime, value int64) bool {
+	return value >= startTime && (value < endTime || endTime == UnboundedEndTime)
+}
+
+// EpochDate converts an epoch date to a date string, see [FormatDate].
+func EpochDate(date int32) string {
+	return FormatDate(time.Unix(int64(date)*int64(EpochSeconds), 0).UTC())
+}
+
+// ParseEpochDate converts a date string to an epoch date, see [ParseDate].
+// Dates that can't be represented, i.e. that would overflow, or collide with
+// the unbounded constants, are an error.
+func ParseEpochDate(s string) (int32, error) {
+	d, err := ParseDate(s)
+	if err != nil {
+		return 0, err
+	}
+	v := floorDiv(d.Unix(), int64(EpochSeconds))
+	if v <= int64(UnboundedStartDate) || v >= int64(UnboundedEndDate) {
+		return 0, errors.New(`epoch date out of range: ` + strconv.Quote(s))
+	}
+	return int32(v), nil
+}
+
+// saturateDate converts v to an epoch date, clamping it to the unbounded
+// constants, if it is out of range.
+func saturateDate(v int64) int32 {
+	return int32(max(min(v, int64(UnboundedEndDate)), int64(UnboundedStartDate)))
+}
+
+// saturateMul returns a*b, for b > 0, clamping it to the unbounded
+// constants, if it overflows.
+func saturateMul(a, b int64) int64 {
+	switch {
+	case a > UnboundedEndTime/b:
+		return UnboundedEndTime
+	case a < UnboundedStartTime/b:
+		return UnboundedStartTime
+	}
+	return a * b
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func ceilDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) == (b < 0)) {
+		q++
+	}
+	return q
+}
@@ -0,0 +1,237 @@
+package baseline
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var epochUnits = [...]struct {
+	unit   EpochUnit
+	toTime func(int64) time.Time
+	toUnit func(time.Time) int64
+}{
+	{EpochNanos, func(v int64) time.Time { return time.Unix(0, v) }, time.Time.UnixNano},
+	{EpochMillis, time.UnixMilli, time.Time.UnixMilli},
+	{EpochSeconds, func(v int64) time.Time { return time.Unix(v, 0) }, time.Time.Unix},
+}
+
+func TestEpochDate(t *testing.T) {
+	for _, v := range DateValues {
+		d, err := ParseEpochDate(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := EpochDate(d); s != v {
+			t.Errorf("expected %s, got %s", v, s)
+		}
+	}
+	if s := EpochDate(0); s != "1970-01-01" {
+		t.Error(s)
+	}
+	if s := EpochDate(-1); s != "1969-12-31" {
+		t.Error(s)
+	}
+}
+
+func TestParseEpochDate_limits(t *testing.T) {
+	for _, tc := range [...]struct {
+		date  string
+		epoch int32
+	}{
+		{"+5881580-07-10", math.MaxInt32 - 1},
+		{"-5877641-06-24", math.MinInt32 + 1},
+	} {
+		if v, err := ParseEpochDate(tc.date); err != nil || v != tc.epoch {
+			t.Errorf("%s: expected %d, got %d, %v", tc.date, tc.epoch, v, err)
+		}
+	}
+	for _, s := range [...]string{
+		"+5881580-07-11", // UnboundedEndDate
+		"-5877641-06-23", // UnboundedStartDate
+		"+5881581-01-01",
+		"-5877642-01-01",
+		"+123456789-01-01",
+	} {
+		if v, err := ParseEpochDate(s); err == nil {
+			t.Errorf("%s: expected error, got %d", s, v)
+		}
+		if _, err := ParseDateRange(s, ""); err == nil {
+			t.Errorf("%s: expected range error", s)
+		}
+	}
+}
+
+func TestEpochUnit_saturate(t *testing.T) {
+	date := func(s string) int32 {
+		d, err := ParseEpochDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	for _, tc := range [...]struct {
+		name string
+		got  int64
+		want int64
+	}{
+		{`nanos start time, after range`, EpochNanos.StartTime(date(`2300-01-01`)), UnboundedEndTime},
+		{`nanos end time, after range`, EpochNanos.EndTime(date(`2262-04-11`)), UnboundedEndTime},
+		{`nanos end time, within range`, EpochNanos.EndTime(date(`2262-04-10`)), int64(date(`2262-04-11`)) * int64(EpochNanos)},
+		{`nanos start time, before range`, EpochNanos.StartTime(date(`1677-09-20`)), UnboundedStartTime},
+		{`nanos start time, within range`, EpochNanos.StartTime(date(`1677-09-22`)), int64(date(`1677-09-22`)) * int64(EpochNanos)},
+		{`nanos end time, before range`, EpochNanos.EndTime(date(`1600-01-01`)), UnboundedStartTime},
+		{`nanos widen start`, EpochNanos.WidenStartTime(math.MinInt64 + 1), UnboundedStartTime},
+		{`nanos widen end`, EpochNanos.WidenEndTime(math.MaxInt64 - 1), UnboundedEndTime},
+		{`seconds start date, after range`, int64(EpochSeconds.StartDate(math.MaxInt64 - 1)), int64(UnboundedEndDate)},
+		{`seconds end date, before range`, int64(EpochSeconds.EndDate(math.MinInt64 + 1)), int64(UnboundedStartDate)},
+		{`millis start time, max date`, EpochMillis.StartTime(math.MaxInt32 - 1), (math.MaxInt32 - 1) * int64(EpochMillis)},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.want, tc.got)
+		}
+	}
+}
+
+// FuzzEpochUnit is a differential test, between the integer-native
+// functions, and their time.Time and string counterparts.
+func FuzzEpochUnit(f *testing.F) {
+	for _, r := range TimestampRangeValues {
+		startTime, _ := ParseTimestamp(r[0])
+		endTime, _ := ParseTimestamp(r[1])
+		for i := range epochUnits {
+			f.Add(uint8(i), epochUnits[i].toUnit(startTime), epochUnits[i].toUnit(endTime), epochUnits[i].toUnit(startTime.Add(oneDay)), int32(19800), int32(19900), int32(19850), false, false)
+			f.Add(uint8(i), epochUnits[i].toUnit(startTime), epochUnits[i].toUnit(endTime), epochUnits[i].toUnit(endTime), int32(-1), int32(0), int32(0), true, false)
+			f.Add(uint8(i), epochUnits[i].toUnit(startTime), epochUnits[i].toUnit(endTime), epochUnits[i].toUnit(endTime.Add(-time.Second)), int32(-1), int32(0), int32(-1), false, true)
+		}
+	}
+	f.Fuzz(func(t *testing.T, unitIndex uint8, startTime, endTime, timeValue int64, startDate, endDate, dateValue int32, ignoreStart, ignoreEnd bool) {
+		u := epochUnits[int(unitIndex)%len(epochUnits)]
+
+		// constrain values to those supported by both implementations
+		maxTime := int64(math.MaxInt64)
+		if maxDays := int64(math.MaxInt32) * int64(u.unit); maxDays/int64(u.unit) == math.MaxInt32 {
+			maxTime = maxDays
+		}
+		maxTime -= int64(u.unit) * 2
+		maxDate := int32(min(int64(math.MaxInt64)/int64(u.unit)-2, math.MaxInt32-1))
+		for _, v := range [...]int64{startTime, endTime, timeValue} {
+			if v > maxTime || v < -maxTime || u.toTime(v).IsZero() {
+				t.Skip()
+			}
+		}
+		for _, v := range [...]int32{startDate, endDate, dateValue} {
+			if v > maxDate || v < -maxDate {
+				t.Skip()
+			}
+		}
+
+		var st, et time.Time
+		if ignoreStart {
+			startTime, startDate = UnboundedStartTime, UnboundedStartDate
+		} else {
+			st = u.toTime(startTime)
+		}
+		if ignoreEnd {
+			endTime, endDate = UnboundedEndTime, UnboundedEndDate
+		} else {
+			et = u.toTime(endTime)
+		}
+
+		epochDate := func(v int32) string {
+			if v == UnboundedStartDate || v == UnboundedEndDate {
+				return ``
+			}
+			return EpochDate(v)
+		}
+		toUnit := func(v time.Time, unbounded int64) int64 {
+			if v == (time.Time{}) {
+				return unbounded
+			}
+			return u.toUnit(v)
+		}
+
+		// narrow
+		sd, ed := u.unit.TimestampToDate(startTime, endTime)
+		if a, b := ExampleTimestampToDate(st, et); epochDate(sd) != a || epochDate(ed) != b {
+			t.Fatalf("TimestampToDate: expected [%s, %s], got [%s, %s]", a, b, epochDate(sd), epochDate(ed))
+		}
+
+		// match (date)
+		if a, b := MatchesEpochDate(sd, ed, dateValue), MatchesDate(epochDate(sd), epochDate(ed), EpochDate(dateValue)); a != b {
+			t.Fatalf("MatchesEpochDate: expected %t, got %t", b, a)
+		}
+
+		// widen
+		ws, we := u.unit.WidenRange(startTime, endTime)
+		if a, b := WidenRange(st, et); toUnit(a, UnboundedStartTime) != ws || toUnit(b, UnboundedEndTime) != we {
+			t.Fatalf("WidenRange: expected [%d, %d), got [%d, %d)", toUnit(a, UnboundedStartTime), toUnit(b, UnboundedEndTime), ws, we)
+		}
+
+		// match (timestamp)
+		if a, b := MatchesEpochTimestamp(startTime, endTime, timeValue), MatchesTimestamp(st, et, u.toTime(timeValue)); a != b {
+			t.Fatalf("MatchesEpochTimestamp: expected %t, got %t", b, a)
+		}
+
+		// convert
+		s, e := u.unit.DateToTimestamp(startDate, endDate)
+		if a, b := ExampleDateToTimestamp(epochDate(startDate), epochDate(endDate)); toUnit(a, UnboundedStartTime) != s || toUnit(b, UnboundedEndTime) != e {
+			t.Fatalf("DateToTimestamp: expected [%d, %d), got [%d, %d)", toUnit(a, UnboundedStartTime), toUnit(b, UnboundedEndTime), s, e)
+		}
+	})
+}
+
+var (
+	benchmarkEpochDate int32
+	benchmarkEpochTime int64
+	benchmarkMatches   bool
+	benchmarkDate      string
+)
+
+func BenchmarkEpochUnit_TimestampToDate(b *testing.B) {
+	b.ReportAllocs()
+	t := time.Date(2024, 7, 16, 15, 0, 0, 0, time.UTC).UnixNano()
+	for i := 0; i < b.N; i++ {
+		s, e := EpochNanos.TimestampToDate(t, t+int64(i))
+		benchmarkEpochDate += s + e
+	}
+}
+
+func BenchmarkEpochUnit_WidenRange(b *testing.B) {
+	b.ReportAllocs()
+	t := time.Date(2024, 7, 16, 15, 0, 0, 0, time.UTC).UnixMilli()
+	for i := 0; i < b.N; i++ {
+		s, e := EpochMillis.WidenRange(t, t+int64(i))
+		benchmarkEpochTime += s + e
+	}
+}
+
+func BenchmarkEpochUnit_DateToTimestamp(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s, e := EpochSeconds.DateToTimestamp(int32(i), int32(i)+7)
+		benchmarkEpochTime += s + e
+	}
+}
+
+func BenchmarkMatchesEpochDate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchmarkMatches = MatchesEpochDate(19800, 19900, int32(i))
+	}
+}
+
+func BenchmarkExampleTimestampToDate(b *testing.B) {
+	b.ReportAllocs()
+	t := time.Date(2024, 7, 16, 15, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		benchmarkDate, _ = ExampleTimestampToDate(t.Add(time.Duration(i)), time.Time{})
+	}
+}
+
+func BenchmarkMatchesDate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchmarkMatches = MatchesDate("2024-03-01", "2024-05-01", "2024-04-01")
+	}
+}
@@ -0,0 +1,351 @@
+package baseline
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run ../cmd/export-fixtures -o testdata/fixtures.json
+//go:generate go run ../cmd/export-fixtures -o testdata/fixtures.tsv
+
+// FixturesVersion is the current (and only supported) version of the
+// [Fixtures] file formats.
+const FixturesVersion = 1
+
+// Fixtures are the test values and expected matches, as used by
+// [TestTimestampToDate] and [TestDateToTimestamp], in a form that may be
+// stored on disk, see [LoadFixtures]. Unset bounds are empty strings.
+type Fixtures struct {
+	Version              int         `json:"version"`
+	DateValues           []string    `json:"dateValues"`
+	DateRangeValues      [][2]string `json:"dateRangeValues"`
+	TimestampValues      []string    `json:"timestampValues"`
+	TimestampRangeValues [][2]string `json:"timestampRangeValues"`
+	// TimestampToDateMatches are (timestamp range, date value) tuples
+	TimestampToDateMatches [][3]string `json:"timestampToDateMatches"`
+	// DateToTimestampMatches are (date range, timestamp value) tuples
+	DateToTimestampMatches [][3]string `json:"dateToTimestampMatches"`
+}
+
+// fixturesTSVKind is a record kind of the TSV format, with the number of
+// fields following the kind.
+type fixturesTSVKind struct {
+	kind   string
+	fields int
+}
+
+// fixturesTSVKinds are the record kinds of the TSV format, in the order
+// they are written, see [Fixtures.WriteTSV].
+var fixturesTSVKinds = [...]fixturesTSVKind{
+	{`date`, 1},
+	{`date_range`, 2},
+	{`timestamp`, 1},
+	{`timestamp_range`, 2},
+	{`timestamp_to_date_match`, 3},
+	{`date_to_timestamp_match`, 3},
+}
+
+// ExampleFixtures returns the built-in example values, i.e. [DateValues],
+// [DateRangeValues], [TimestampValues], [TimestampRangeValues], and
+// [ExampleMatches], as [Fixtures].
+func ExampleFixtures() *Fixtures {
+	x := Fixtures{
+		Version:              FixturesVersion,
+		DateValues:           slices.Clone(DateValues),
+		DateRangeValues:      slices.Clone(DateRangeValues),
+		TimestampValues:      slices.Clone(TimestampValues),
+		TimestampRangeValues: slices.Clone(TimestampRangeValues),
+	}
+	for k := range ExampleMatches {
+		// the values of TimestampToDate tuples are dates
+		if ValidateDate(k[2]) == nil {
+			x.TimestampToDateMatches = append(x.TimestampToDateMatches, k)
+		} else {
+			x.DateToTimestampMatches = append(x.DateToTimestampMatches, k)
+		}
+	}
+	slices.SortFunc(x.TimestampToDateMatches, compareMatches)
+	slices.SortFunc(x.DateToTimestampMatches, compareMatches)
+	return &x
+}
+
+// Matches returns all the expected matches, in the form of [ExampleMatches].
+func (x *Fixtures) Matches() map[[3]string]struct{} {
+	matches := make(map[[3]string]struct{}, len(x.TimestampToDateMatches)+len(x.DateToTimestampMatches))
+	for _, k := range x.TimestampToDateMatches {
+		matches[k] = struct{}{}
+	}
+	for _, k := range x.DateToTimestampMatches {
+		matches[k] = struct{}{}
+	}
+	return matches
+}
+
+// Validate checks the version, and that every date and timestamp is valid.
+func (x *Fixtures) Validate() error {
+	if x.Version != FixturesVersion {
+		return fmt.Errorf(`fixtures: unsupported version %d`, x.Version)
+	}
+	var errs []error
+	check := func(field string, i int, validate func(string) error, values ...string) {
+		for _, v := range values {
+			if v == `` && len(values) > 1 {
+				// unset bound
+				continue
+			}
+			if err := validate(v); err != nil {
+				errs = append(errs, fmt.Errorf(`fixtures: %s[%d]: %q: %w`, field, i, v, err))
+			}
+		}
+	}
+	validateTimestamp := func(s string) error {
+		_, err := ParseTimestamp(s)
+		return err
+	}
+	for i, v := range x.DateValues {
+		check(`dateValues`, i, ValidateDate, v)
+	}
+	for i, v := range x.DateRangeValues {
+		check(`dateRangeValues`, i, ValidateDate, v[:]...)
+	}
+	for i, v := range x.TimestampValues {
+		check(`timestampValues`, i, validateTimestamp, v)
+	}
+	for i, v := range x.TimestampRangeValues {
+		check(`timestampRangeValues`, i, validateTimestamp, v[:]...)
+	}
+	for i, v := range x.TimestampToDateMatches {
+		check(`timestampToDateMatches`, i, validateTimestamp, v[:2]...)
+		check(`timestampToDateMatches`, i, ValidateDate, v[2])
+	}
+	for i, v := range x.DateToTimestampMatches {
+		check(`dateToTimestampMatches`, i, ValidateDate, v[:2]...)
+		check(`dateToTimestampMatches`, i, validateTimestamp, v[2])
+	}
+	return errors.Join(errs...)
+}
+
+// ParseFixturesJSON reads [Fixtures] from JSON, validating the result.
+func ParseFixturesJSON(r io.Reader) (*Fixtures, error) {
+	var x Fixtures
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&x); err != nil {
+		return nil, fmt.Errorf(`fixtures: %w`, err)
+	}
+	if err := x.Validate(); err != nil {
+		return nil, err
+	}
+	return &x, nil
+}
+
+// WriteJSON writes x as indented JSON.
+func (x *Fixtures) WriteJSON(w io.Writer) error {
+	e := json.NewEncoder(w)
+	e.SetIndent(``, "\t")
+	return e.Encode(x)
+}
+
+// ParseFixturesTSV reads [Fixtures] from TSV, validating the result. Each
+// line is a record, consisting of a kind, followed by tab-separated fields,
+// e.g. "date_range\t2024-01-01\t2024-01-31". The first record must be the
+// version, e.g. "version\t1". Blank lines, and lines starting with "#", are
+// ignored. See [Fixtures.WriteTSV] for the supported kinds.
+func ParseFixturesTSV(r io.Reader) (*Fixtures, error) {
+	var x Fixtures
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := s.Text()
+		if strings.TrimSpace(text) == `` || strings.HasPrefix(text, `#`) {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if err := x.parseRecord(fields); err != nil {
+			return nil, fmt.Errorf(`fixtures line %d: %w`, line, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if err := x.Validate(); err != nil {
+		return nil, err
+	}
+	return &x, nil
+}
+
+func (x *Fixtures) parseRecord(fields []string) error {
+	kind := fields[0]
+	if kind == `version` {
+		if x.Version != 0 {
+			return errors.New(`duplicate version`)
+		}
+		if len(fields) != 2 {
+			return fmt.Errorf(`%s: expected 1 field, got %d`, kind, len(fields)-1)
+		}
+		v, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, kind, err)
+		}
+		if v != FixturesVersion {
+			return fmt.Errorf(`%s: unsupported version %d`, kind, v)
+		}
+		x.Version = v
+		return nil
+	}
+	if x.Version == 0 {
+		return errors.New(`version must be the first record`)
+	}
+	i := slices.IndexFunc(fixturesTSVKinds[:], func(v fixturesTSVKind) bool {
+		return v.kind == kind
+	})
+	if i == -1 {
+		return fmt.Errorf(`unknown kind %q`, kind)
+	}
+	if n := fixturesTSVKinds[i].fields; len(fields)-1 != n {
+		return fmt.Errorf(`%s: expected %d field(s), got %d`, kind, n, len(fields)-1)
+	}
+	switch kind {
+	case `date`:
+		x.DateValues = append(x.DateValues, fields[1])
+	case `date_range`:
+		x.DateRangeValues = append(x.DateRangeValues, [2]string(fields[1:]))
+	case `timestamp`:
+		x.TimestampValues = append(x.TimestampValues, fields[1])
+	case `timestamp_range`:
+		x.TimestampRangeValues = append(x.TimestampRangeValues, [2]string(fields[1:]))
+	case `timestamp_to_date_match`:
+		x.TimestampToDateMatches = append(x.TimestampToDateMatches, [3]string(fields[1:]))
+	case `date_to_timestamp_match`:
+		x.DateToTimestampMatches = append(x.DateToTimestampMatches, [3]string(fields[1:]))
+	}
+	return nil
+}
+
+// WriteTSV writes x in the format read by [ParseFixturesTSV], with records
+// of each kind, in the order: date, date_range, timestamp, timestamp_range,
+// timestamp_to_date_match, date_to_timestamp_match.
+func (x *Fixtures) WriteTSV(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(b, "version\t%d\n", x.Version)
+	for _, kind := range fixturesTSVKinds {
+		var records [][]string
+		switch kind.kind {
+		case `date`:
+			for _, v := range x.DateValues {
+				records = append(records, []string{v})
+			}
+		case `date_range`:
+			for _, v := range x.DateRangeValues {
+				records = append(records, v[:])
+			}
+		case `timestamp`:
+			for _, v := range x.TimestampValues {
+				records = append(records, []string{v})
+			}
+		case `timestamp_range`:
+			for _, v := range x.TimestampRangeValues {
+				records = append(records, v[:])
+			}
+		case `timestamp_to_date_match`:
+			for _, v := range x.TimestampToDateMatches {
+				records = append(records, v[:])
+			}
+		case `date_to_timestamp_match`:
+			for _, v := range x.DateToTimestampMatches {
+				records = append(records, v[:])
+			}
+		}
+		for _, fields := range records {
+			_, _ = b.WriteString(kind.kind)
+			for _, v := range fields {
+				if strings.ContainsAny(v, "\t\n") {
+					return fmt.Errorf(`fixtures: %s: invalid value %q`, kind.kind, v)
+				}
+				_ = b.WriteByte('\t')
+				_, _ = b.WriteString(v)
+			}
+			_ = b.WriteByte('\n')
+		}
+	}
+	return b.Flush()
+}
+
+// TimestampToDateCases returns each of [RangeTestCases], of the timestamp
+// ranges and date values, named as per [CaseResult], omitting any that are
+// outside the domain of [FuzzTimestampToDate], see
+// [TimestampToDateCase.Validate].
+func (x *Fixtures) TimestampToDateCases() (names []string, cases []TimestampToDateCase) {
+	RangeTestCases(x.TimestampRangeValues, x.DateValues, func(r [2]string, value string) bool {
+		bounds, err := parseTimestampRanges([][2]string{r})
+		if err != nil {
+			return true
+		}
+		c := TimestampToDateCase{bounds[0][0], bounds[0][1], value}
+		if c.Validate() == nil {
+			names = append(names, r[0]+`-`+r[1]+`-`+value)
+			cases = append(cases, c)
+		}
+		return true
+	})
+	return
+}
+
+// WriteTimestampToDateCasesTSV writes cases as tab-separated values, with
+// a header comment, then one line per case, with the columns: name,
+// start_time, end_time, value, start_date, end_date, matches. The last three
+// columns are the expected results, per [ExampleTimestampToDate] and
+// [OracleMatchesDate], and unset bounds are empty. The names must
+// correspond to cases.
+func WriteTimestampToDateCasesTSV(w io.Writer, names []string, cases []TimestampToDateCase) error {
+	b := bufio.NewWriter(w)
+	_, _ = b.WriteString("# name\tstart_time\tend_time\tvalue\tstart_date\tend_date\tmatches\n")
+	for i, c := range cases {
+		if strings.ContainsAny(names[i], "\t\n") {
+			return fmt.Errorf(`invalid name %q`, names[i])
+		}
+		startDate, endDate := ExampleTimestampToDate(c.StartTime, c.EndTime)
+		_, _ = fmt.Fprintf(b, "%s\t%s\t%s\t%s\t%s\t%s\t%t\n",
+			names[i],
+			formatOptionalTimestamp(c.StartTime),
+			formatOptionalTimestamp(c.EndTime),
+			c.Value,
+			startDate,
+			endDate,
+			OracleMatchesDate(c.StartTime, c.EndTime, c.Value),
+		)
+	}
+	return b.Flush()
+}
+
+// LoadFixtures reads [Fixtures] from a file, using [ParseFixturesJSON] if it
+// has a ".json" extension, or [ParseFixturesTSV] if it has a ".tsv"
+// extension.
+func LoadFixtures(name string) (*Fixtures, error) {
+	var parse func(io.Reader) (*Fixtures, error)
+	switch ext := filepath.Ext(name); ext {
+	case `.json`:
+		parse = ParseFixturesJSON
+	case `.tsv`:
+		parse = ParseFixturesTSV
+	default:
+		return nil, fmt.Errorf(`fixtures: unsupported file extension %q`, ext)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	x, err := parse(f)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, name, err)
+	}
+	return x, nil
+}
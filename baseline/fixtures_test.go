@@ -0,0 +1,137 @@
+package baseline
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExampleFixtures(t *testing.T) {
+	x := ExampleFixtures()
+	if err := x.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(x.Matches(), ExampleMatches) {
+		t.Error("matches differ from ExampleMatches")
+	}
+	timestampToDate, dateToTimestamp, err := GenerateExampleMatches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(x.TimestampToDateMatches, timestampToDate) || !reflect.DeepEqual(x.DateToTimestampMatches, dateToTimestamp) {
+		t.Error("matches are not partitioned by direction")
+	}
+}
+
+func TestFixtures_roundTrip(t *testing.T) {
+	expected := ExampleFixtures()
+	for _, tc := range [...]struct {
+		name  string
+		write func(x *Fixtures, b *bytes.Buffer) error
+		parse func(b *bytes.Buffer) (*Fixtures, error)
+	}{
+		{`json`, func(x *Fixtures, b *bytes.Buffer) error { return x.WriteJSON(b) }, func(b *bytes.Buffer) (*Fixtures, error) { return ParseFixturesJSON(b) }},
+		{`tsv`, func(x *Fixtures, b *bytes.Buffer) error { return x.WriteTSV(b) }, func(b *bytes.Buffer) (*Fixtures, error) { return ParseFixturesTSV(b) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tc.write(expected, &b); err != nil {
+				t.Fatal(err)
+			}
+			actual, err := tc.parse(&b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected %+v, got %+v", expected, actual)
+			}
+		})
+	}
+}
+
+// TestLoadFixtures_testdata ensures the exported fixtures are up to date.
+func TestLoadFixtures_testdata(t *testing.T) {
+	expected := ExampleFixtures()
+	for _, name := range [...]string{`testdata/fixtures.json`, `testdata/fixtures.tsv`} {
+		actual, err := LoadFixtures(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: out of date (run go generate)", name)
+		}
+	}
+}
+
+func TestParseFixturesTSV_invalid(t *testing.T) {
+	for _, tc := range [...]struct {
+		input string
+		err   string
+	}{
+		{"date\t2024-01-01\n", `version must be the first record`},
+		{"version\t2\n", `unsupported version 2`},
+		{"version\t1\nversion\t1\n", `duplicate version`},
+		{"version\t1\nunknown\t1\n", `unknown kind "unknown"`},
+		{"version\t1\ndate_range\t2024-01-01\n", `date_range: expected 2 field(s), got 1`},
+		{"version\t1\n\n# comment\ntimestamp_range\t\t2024-01-01\n", `timestampRangeValues[0]: "2024-01-01"`},
+		{"", `unsupported version 0`},
+	} {
+		_, err := ParseFixturesTSV(strings.NewReader(tc.input))
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%q: expected error containing %q, got %v", tc.input, tc.err, err)
+		}
+	}
+}
+
+func TestParseFixturesJSON_invalid(t *testing.T) {
+	for _, input := range [...]string{
+		`{"version": 2}`,
+		`{"version": 1, "unknown": true}`,
+		`{"version": 1, "dateToTimestampMatches": [["2024-01-01", "", "2024-01-01"]]}`,
+	} {
+		if _, err := ParseFixturesJSON(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
+
+func TestLoadFixtures_extension(t *testing.T) {
+	name := t.TempDir() + `/fixtures.txt`
+	if err := os.WriteFile(name, []byte("version\t1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFixtures(name); err == nil || !strings.Contains(err.Error(), `unsupported file extension ".txt"`) {
+		t.Fatal(err)
+	}
+}
+
+func TestFixtures_TimestampToDateCases(t *testing.T) {
+	names, cases := ExampleFixtures().TimestampToDateCases()
+	if len(names) != len(cases) || len(cases) == 0 {
+		t.Fatalf("unexpected cases: %d names, %d cases", len(names), len(cases))
+	}
+	for _, c := range cases {
+		if err := c.Validate(); err != nil {
+			t.Fatalf("%s: %v", c, err)
+		}
+	}
+	// the range around the leap second is less than a day
+	if strings.Contains(strings.Join(names, "\n"), `2016-12-31T23:59:59Z-2017-01-01T00:00:00Z-`) {
+		t.Error("expected ranges of less than a day to be omitted")
+	}
+
+	var b bytes.Buffer
+	if err := WriteTimestampToDateCasesTSV(&b, names[:2], cases[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if s := b.String(); s != "# name\tstart_time\tend_time\tvalue\tstart_date\tend_date\tmatches\n"+
+		"2024-01-01T00:00:00Z-2024-01-31T23:59:59Z-2024-01-01\t2024-01-01T00:00:00Z\t2024-01-31T23:59:59Z\t2024-01-01\t2024-01-01\t2024-01-30\ttrue\n"+
+		"2024-01-01T00:00:00Z-2024-01-31T23:59:59Z-2024-12-25\t2024-01-01T00:00:00Z\t2024-01-31T23:59:59Z\t2024-12-25\t2024-01-01\t2024-01-30\tfalse\n" {
+		t.Errorf("unexpected TSV:\n%s", s)
+	}
+	if err := WriteTimestampToDateCasesTSV(&b, []string{"a\tb"}, cases[:1]); err == nil {
+		t.Error("expected error")
+	}
+}
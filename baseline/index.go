@@ -0,0 +1,201 @@
+package baseline
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+type (
+	// DateRangeIndex is an interval index, over many date ranges, which finds
+	// the ranges containing a given date, equivalent to calling
+	// [MatchesDate] for every range.
+	DateRangeIndex struct {
+		index intervalIndex[int64]
+	}
+
+	// TimestampRangeIndex is an interval index, over many timestamp ranges,
+	// which finds the ranges containing a given timestamp, equivalent to
+	// calling [MatchesTimestamp] for every range.
+	TimestampRangeIndex struct {
+		index intervalIndex[timestampKey]
+	}
+
+	// intervalIndex is a static, augmented interval tree, of closed
+	// intervals, implemented as an implicit balanced binary search tree,
+	// over the intervals sorted by start.
+	intervalIndex[K any] struct {
+		compare func(a, b K) int
+		nodes   []intervalNode[K]
+	}
+
+	intervalNode[K any] struct {
+		start, end K
+		// maxEnd is the maximum end within the subtree rooted at this node
+		maxEnd K
+		id     int
+	}
+
+	// timestampKey is a comparable representation of any time.Time, that is
+	// able to represent unbounded values, as the extremes.
+	timestampKey struct {
+		sec  int64
+		nsec int64
+	}
+)
+
+var (
+	minTimestampKey = timestampKey{math.MinInt64, 0}
+	maxTimestampKey = timestampKey{math.MaxInt64, 0}
+)
+
+// NewDateRangeIndex indexes date ranges, which are identified by their index
+// within ranges. Unset (empty) bounds are supported.
+func NewDateRangeIndex(ranges [][2]string) (*DateRangeIndex, error) {
+	nodes := make([]intervalNode[int64], len(ranges))
+	for i, r := range ranges {
+		nodes[i] = intervalNode[int64]{start: math.MinInt64, end: math.MaxInt64, id: i}
+		if r[0] != `` {
+			v, err := dateKey(r[0])
+			if err != nil {
+				return nil, err
+			}
+			nodes[i].start = v
+		}
+		if r[1] != `` {
+			v, err := dateKey(r[1])
+			if err != nil {
+				return nil, err
+			}
+			nodes[i].end = v
+		}
+	}
+	return &DateRangeIndex{newIntervalIndex(cmp.Compare[int64], nodes)}, nil
+}
+
+// NewTimestampToDateIndex indexes timestamp ranges, by first converting them
+// to date ranges, using convert.
+func NewTimestampToDateIndex(ranges [][2]time.Time, convert TimestampToDate) (*DateRangeIndex, error) {
+	dateRanges := make([][2]string, len(ranges))
+	for i, r := range ranges {
+		dateRanges[i][0], dateRanges[i][1] = convert(r[0], r[1])
+	}
+	return NewDateRangeIndex(dateRanges)
+}
+
+// Lookup appends the identifiers of the ranges containing value to dst, in
+// ascending order.
+func (x *DateRangeIndex) Lookup(dst []int, value string) ([]int, error) {
+	v, err := dateKey(value)
+	if err != nil {
+		return dst, err
+	}
+	return x.index.lookup(dst, v), nil
+}
+
+// NewTimestampRangeIndex indexes timestamp ranges, which are identified by
+// their index within ranges. The zero value is treated as unset.
+func NewTimestampRangeIndex(ranges [][2]time.Time) *TimestampRangeIndex {
+	nodes := make([]intervalNode[timestampKey], len(ranges))
+	for i, r := range ranges {
+		nodes[i] = intervalNode[timestampKey]{start: minTimestampKey, end: maxTimestampKey, id: i}
+		if r[0] != (time.Time{}) {
+			nodes[i].start = newTimestampKey(r[0])
+		}
+		if r[1] != (time.Time{}) {
+			// convert the exclusive end to an inclusive end
+			nodes[i].end = newTimestampKey(r[1].Add(-time.Nanosecond))
+		}
+	}
+	return &TimestampRangeIndex{newIntervalIndex(timestampKey.compare, nodes)}
+}
+
+// NewDateToTimestampIndex indexes date ranges, by first converting them to
+// timestamp ranges, using convert.
+func NewDateToTimestampIndex(ranges [][2]string, convert DateToTimestamp) *TimestampRangeIndex {
+	timestampRanges := make([][2]time.Time, len(ranges))
+	for i, r := range ranges {
+		timestampRanges[i][0], timestampRanges[i][1] = convert(r[0], r[1])
+	}
+	return NewTimestampRangeIndex(timestampRanges)
+}
+
+// Lookup appends the identifiers of the ranges containing value to dst, in
+// ascending order.
+func (x *TimestampRangeIndex) Lookup(dst []int, value time.Time) []int {
+	return x.index.lookup(dst, newTimestampKey(value))
+}
+
+func newIntervalIndex[K any](compare func(a, b K) int, nodes []intervalNode[K]) intervalIndex[K] {
+	slices.SortStableFunc(nodes, func(a, b intervalNode[K]) int {
+		return compare(a.start, b.start)
+	})
+	x := intervalIndex[K]{compare: compare, nodes: nodes}
+	x.build(0, len(nodes))
+	return x
+}
+
+// build initialises maxEnd for the subtree [lo, hi), returning the index of
+// the root node, or -1 if empty.
+func (x *intervalIndex[K]) build(lo, hi int) int {
+	if lo >= hi {
+		return -1
+	}
+	mid := int(uint(lo+hi) >> 1)
+	n := &x.nodes[mid]
+	n.maxEnd = n.end
+	for _, child := range [...]int{x.build(lo, mid), x.build(mid+1, hi)} {
+		if child != -1 && x.compare(x.nodes[child].maxEnd, n.maxEnd) > 0 {
+			n.maxEnd = x.nodes[child].maxEnd
+		}
+	}
+	return mid
+}
+
+func (x *intervalIndex[K]) lookup(dst []int, v K) []int {
+	offset := len(dst)
+	dst = x.search(dst, v, 0, len(x.nodes))
+	slices.Sort(dst[offset:])
+	return dst
+}
+
+func (x *intervalIndex[K]) search(dst []int, v K, lo, hi int) []int {
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		n := &x.nodes[mid]
+		if x.compare(n.maxEnd, v) < 0 {
+			// every interval in this subtree ends before v
+			break
+		}
+		dst = x.search(dst, v, lo, mid)
+		if x.compare(n.start, v) > 0 {
+			// this interval, and all to the right, start after v
+			break
+		}
+		if x.compare(n.end, v) >= 0 {
+			dst = append(dst, n.id)
+		}
+		lo = mid + 1
+	}
+	return dst
+}
+
+func newTimestampKey(t time.Time) timestampKey {
+	return timestampKey{t.Unix(), int64(t.Nanosecond())}
+}
+
+func (x timestampKey) compare(y timestampKey) int {
+	if c := cmp.Compare(x.sec, y.sec); c != 0 {
+		return c
+	}
+	return cmp.Compare(x.nsec, y.nsec)
+}
+
+func dateKey(s string) (int64, error) {
+	d, err := ParseDate(s)
+	if err != nil {
+		return 0, err
+	}
+	return floorDiv(d.Unix(), int64(EpochSeconds)), nil
+}
@@ -0,0 +1,150 @@
+package baseline
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+func mustParseTimestamps(t testing.TB, values ...string) []time.Time {
+	result := make([]time.Time, len(values))
+	for i, v := range values {
+		if v == `` {
+			continue
+		}
+		var err error
+		result[i], err = ParseTimestamp(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return result
+}
+
+func TestNewTimestampToDateIndex(t *testing.T) {
+	var ranges [][2]time.Time
+	for _, r := range rangeVariants(TimestampRangeValues) {
+		ranges = append(ranges, [2]time.Time(mustParseTimestamps(t, r[:]...)))
+	}
+	index, err := NewTimestampToDateIndex(ranges, ExampleTimestampToDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual, expected []int
+	for _, v := range append(slices.Clone(DateValues), "2000-01-01", "2030-01-01", "2024-01-31", "2024-02-01") {
+		expected = expected[:0]
+		for i, r := range ranges {
+			startDate, endDate := ExampleTimestampToDate(r[0], r[1])
+			if MatchesDate(startDate, endDate, v) {
+				expected = append(expected, i)
+			}
+		}
+		actual, err = index.Lookup(actual[:0], v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(actual, expected) {
+			t.Errorf("%s: expected %v, got %v", v, expected, actual)
+		}
+	}
+}
+
+func TestNewDateToTimestampIndex(t *testing.T) {
+	ranges := rangeVariants(DateRangeValues)
+	index := NewDateToTimestampIndex(ranges, ExampleDateToTimestamp)
+	values := append(
+		mustParseTimestamps(t, TimestampValues...),
+		mustParseTimestamps(t,
+			"2000-01-01T00:00:00Z",
+			"2024-01-31T23:59:59.999999999Z",
+			"2024-02-01T00:00:00Z",
+			"2024-02-29T23:59:59.999999999Z",
+			"2024-03-01T00:00:00+00:01",
+		)...,
+	)
+	var actual, expected []int
+	for _, v := range values {
+		expected = expected[:0]
+		for i, r := range ranges {
+			startTime, endTime := ExampleDateToTimestamp(r[0], r[1])
+			if MatchesTimestamp(startTime, endTime, v) {
+				expected = append(expected, i)
+			}
+		}
+		if actual = index.Lookup(actual[:0], v); !slices.Equal(actual, expected) {
+			t.Errorf("%s: expected %v, got %v", FormatTimestamp(v), expected, actual)
+		}
+	}
+}
+
+func TestNewDateRangeIndex_invalid(t *testing.T) {
+	if _, err := NewDateRangeIndex([][2]string{{"2024-01-01", "2024-13-01"}}); err == nil {
+		t.Error("expected error")
+	}
+	index, err := NewDateRangeIndex(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := index.Lookup(nil, "2024-01-01"); err != nil || len(v) != 0 {
+		t.Error(v, err)
+	}
+	if _, err := index.Lookup(nil, "invalid"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestTimestampRangeIndex_random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	randomTime := func() time.Time {
+		return base.Add(time.Duration(r.Int64N(int64(100 * time.Hour)))).In(time.FixedZone(``, r.IntN(48)*1800-43200))
+	}
+	ranges := make([][2]time.Time, 300)
+	for i := range ranges {
+		ranges[i] = [2]time.Time{randomTime(), randomTime()}
+		switch r.IntN(8) {
+		case 0:
+			ranges[i][0] = time.Time{}
+		case 1:
+			ranges[i][1] = time.Time{}
+		}
+	}
+	index := NewTimestampRangeIndex(ranges)
+	var actual, expected []int
+	for range 1000 {
+		v := randomTime()
+		if r.IntN(4) == 0 {
+			// exactly on a bound
+			v = ranges[r.IntN(len(ranges))][r.IntN(2)]
+		}
+		expected = expected[:0]
+		for i, r := range ranges {
+			if MatchesTimestamp(r[0], r[1], v) {
+				expected = append(expected, i)
+			}
+		}
+		if actual = index.Lookup(actual[:0], v); !slices.Equal(actual, expected) {
+			t.Fatalf("%s: expected %v, got %v", FormatTimestamp(v), expected, actual)
+		}
+	}
+}
+
+func BenchmarkDateRangeIndex_Lookup(b *testing.B) {
+	var ranges [][2]string
+	for i := range 500 {
+		start := EpochDate(int32(19000 + i*3))
+		end := EpochDate(int32(19000 + i*3 + i%60))
+		ranges = append(ranges, [2]string{start, end})
+	}
+	index, err := NewDateRangeIndex(ranges)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var dst []int
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst, _ = index.Lookup(dst[:0], DateValues[i%len(DateValues)])
+	}
+}
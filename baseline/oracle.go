@@ -0,0 +1,116 @@
+package baseline
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+//go:generate go run ../cmd/generate-matches -o example_matches.go
+
+// OracleMatchesDate returns true if every instant in the UTC day of value
+// lies within the timestamp range [startTime, endTime), where zero values
+// are unbounded. It is the expected result of converting the range, using a
+// [TimestampToDate] implementation, then matching using [MatchesDate].
+//
+// Unlike [ExampleTimestampToDate], it is implemented from first principles,
+// for use as an oracle. Since the range is contiguous, it is sufficient to
+// check the first and last instants of the day.
+func OracleMatchesDate(startTime, endTime time.Time, value string) bool {
+	day, err := ParseDate(value)
+	if err != nil {
+		panic(err)
+	}
+	first := day
+	last := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	return (startTime == (time.Time{}) || !first.Before(startTime)) &&
+		(endTime == (time.Time{}) || last.Before(endTime))
+}
+
+// OracleMatchesTimestamp returns true if the UTC date of value lies within
+// the date range [startDate, endDate], where empty strings are unbounded.
+// It is the expected result of converting the range, using a
+// [DateToTimestamp] implementation, then matching using [MatchesTimestamp].
+func OracleMatchesTimestamp(startDate, endDate string, value time.Time) bool {
+	date := value.UTC()
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return (startDate == `` || !date.Before(mustParseDate(startDate))) &&
+		(endDate == `` || !date.After(mustParseDate(endDate)))
+}
+
+// TimestampToDateMatches computes the expected matches, as used by
+// [TestTimestampToDate], for the given timestamp ranges and date values,
+// using [OracleMatchesDate], returning them in sorted order.
+// It returns an error if any timestamp or date is invalid.
+func TimestampToDateMatches(ranges [][2]string, values []string) (matches [][3]string, err error) {
+	for _, v := range values {
+		if err = ValidateDate(v); err != nil {
+			return nil, err
+		}
+	}
+	RangeTestCases(ranges, values, func(r [2]string, value string) bool {
+		var bounds [][2]time.Time
+		if bounds, err = parseTimestampRanges([][2]string{r}); err != nil {
+			return false
+		}
+		if OracleMatchesDate(bounds[0][0], bounds[0][1], value) {
+			matches = append(matches, [3]string{r[0], r[1], value})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(matches, compareMatches)
+	return matches, nil
+}
+
+// DateToTimestampMatches computes the expected matches, as used by
+// [TestDateToTimestamp], for the given date ranges and timestamp values,
+// using [OracleMatchesTimestamp], returning them in sorted order.
+// It returns an error if any date or timestamp is invalid.
+func DateToTimestampMatches(ranges [][2]string, values []string) (matches [][3]string, err error) {
+	for _, r := range ranges {
+		for _, v := range r {
+			if v != `` {
+				if err = ValidateDate(v); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	RangeTestCases(ranges, values, func(r [2]string, value string) bool {
+		var v time.Time
+		if v, err = ParseTimestamp(value); err != nil {
+			return false
+		}
+		if OracleMatchesTimestamp(r[0], r[1], v) {
+			matches = append(matches, [3]string{r[0], r[1], value})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(matches, compareMatches)
+	return matches, nil
+}
+
+// GenerateExampleMatches computes [ExampleMatches], from the example values,
+// using [TimestampToDateMatches] and [DateToTimestampMatches].
+func GenerateExampleMatches() (timestampToDate, dateToTimestamp [][3]string, err error) {
+	if timestampToDate, err = TimestampToDateMatches(TimestampRangeValues, DateValues); err != nil {
+		return
+	}
+	dateToTimestamp, err = DateToTimestampMatches(DateRangeValues, TimestampValues)
+	return
+}
+
+func compareMatches(a, b [3]string) int {
+	for i := range a {
+		if c := cmp.Compare(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
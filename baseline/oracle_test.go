@@ -0,0 +1,90 @@
+package baseline
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestGenerateExampleMatches(t *testing.T) {
+	timestampToDate, dateToTimestamp, err := GenerateExampleMatches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := make(map[[3]string]struct{})
+	for _, m := range append(timestampToDate, dateToTimestamp...) {
+		if _, ok := actual[m]; ok {
+			t.Errorf("duplicate match: %q", m)
+		}
+		actual[m] = struct{}{}
+	}
+	for k := range ExampleMatches {
+		if _, ok := actual[k]; !ok {
+			t.Errorf("missing match: %q", k)
+		}
+	}
+	for k := range actual {
+		if _, ok := ExampleMatches[k]; !ok {
+			t.Errorf("unexpected match: %q (run go generate)", k)
+		}
+	}
+}
+
+func TestTimestampToDateMatches_invalid(t *testing.T) {
+	if _, err := TimestampToDateMatches([][2]string{{"2024-01-01", ""}}, DateValues); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := TimestampToDateMatches(TimestampRangeValues, []string{"2024-1-1"}); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := DateToTimestampMatches([][2]string{{"2024-01-01T00:00:00Z", ""}}, TimestampValues); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := DateToTimestampMatches(DateRangeValues, []string{"2024-01-01"}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+// TestOracle_random cross-checks the oracle functions against the example
+// implementations, including bounds that are near or on UTC midnight.
+func TestOracle_random(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	randomTime := func() time.Time {
+		v := base.Add(time.Duration(r.Int64N(int64(20 * oneDay))))
+		switch r.IntN(4) {
+		case 0:
+			v = WidenStartTime(v)
+		case 1:
+			v = WidenStartTime(v).Add(time.Duration(r.IntN(3)-1) * time.Nanosecond)
+		}
+		if r.IntN(8) == 0 {
+			return time.Time{}
+		}
+		return v.In(time.FixedZone(``, r.IntN(48)*1800-43200))
+	}
+	randomDate := func() string {
+		if r.IntN(8) == 0 {
+			return ``
+		}
+		return FormatDate(base.AddDate(0, 0, r.IntN(20)))
+	}
+	for range 10000 {
+		startTime, endTime := randomTime(), randomTime()
+		value := FormatDate(base.AddDate(0, 0, r.IntN(20)))
+		startDate, endDate := ExampleTimestampToDate(startTime, endTime)
+		if expected, actual := OracleMatchesDate(startTime, endTime, value), MatchesDate(startDate, endDate, value); expected != actual {
+			t.Fatalf("[%s, %s) matching %s: expected %t", formatBound(startTime), formatBound(endTime), value, expected)
+		}
+
+		startDate, endDate = randomDate(), randomDate()
+		v := randomTime()
+		if v == (time.Time{}) {
+			continue
+		}
+		startTime, endTime = ExampleDateToTimestamp(startDate, endDate)
+		if expected, actual := OracleMatchesTimestamp(startDate, endDate, v), MatchesTimestamp(startTime, endTime, v); expected != actual {
+			t.Fatalf("[%s, %s] matching %s: expected %t", startDate, endDate, FormatTimestamp(v), expected)
+		}
+	}
+}
@@ -0,0 +1,484 @@
+package baseline
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Report is the structured result of verifying an implementation, as
+// produced by [VerifyTimestampToDate], [VerifyDateToTimestamp] and
+// [VerifyContiguity].
+type Report struct {
+	// Name identifies the suite, e.g. "TimestampToDate".
+	Name string
+	// Cases are all the cases that were run, in order.
+	Cases []CaseResult
+	// Duration is the total time taken.
+	Duration time.Duration
+	// Err is set if the verification did not complete, e.g. the context was
+	// canceled, or the external command failed.
+	Err error
+}
+
+// Passed returns the number of cases that passed.
+func (x *Report) Passed() (n int) {
+	for _, v := range x.Cases {
+		if v.Err == nil {
+			n++
+		}
+	}
+	return
+}
+
+// Failed returns the number of cases that failed.
+func (x *Report) Failed() int {
+	return len(x.Cases) - x.Passed()
+}
+
+// Failure returns a [VerificationError] if any cases failed, joined with
+// [Report.Err], or nil if the verification completed successfully.
+func (x *Report) Failure() error {
+	var verr *VerificationError
+	for _, v := range x.Cases {
+		if v.Err == nil {
+			continue
+		}
+		if verr == nil {
+			verr = &VerificationError{Passed: x.Passed(), Failed: x.Failed()}
+		}
+		verr.Failures = append(verr.Failures, v)
+	}
+	if verr == nil {
+		return x.Err
+	}
+	return errors.Join(verr, x.Err)
+}
+
+// VerifyTimestampToDate is a variant of [TestTimestampToDateExternal] that
+// returns a [Report], rather than printing the results. Up to parallelism
+// cases are run concurrently, in which case convert must be safe for
+// concurrent use. The order of the cases does not depend on parallelism.
+// The first few failures are shrunk, see [CaseResult.Minimal].
+func VerifyTimestampToDate(ctx context.Context, parallelism int, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert TimestampToDate) *Report {
+	return VerifyTimestampToDatePerCase(ctx, parallelism, ranges, values, matches, func(int) TimestampToDate { return convert })
+}
+
+// VerifyTimestampToDatePerCase is a variant of [VerifyTimestampToDate] that
+// calls convert with the index of each case, in [Report.Cases], to get the
+// conversion function for that case (including shrinking), e.g. to
+// attribute diagnostics, such as [CaseResult.Stderr], to it.
+func VerifyTimestampToDatePerCase(ctx context.Context, parallelism int, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert func(i int) TimestampToDate) *Report {
+	report := verifyCases(ctx, parallelism, `TimestampToDate`, ranges, values, func(i int, r [2]string, value string) CaseResult {
+		return checkTimestampToDateCase(r, value, matches, convert(i))
+	}, nil)
+	shrinkFailures(ctx, report, convert)
+	return report
+}
+
+// VerifyDateToTimestamp is a variant of [TestDateToTimestampExternal] that
+// returns a [Report], rather than printing the results. See
+// [VerifyTimestampToDate] for the behavior of parallelism.
+func VerifyDateToTimestamp(ctx context.Context, parallelism int, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert DateToTimestamp) *Report {
+	return VerifyDateToTimestampPerCase(ctx, parallelism, ranges, values, matches, func(int) DateToTimestamp { return convert })
+}
+
+// VerifyDateToTimestampPerCase is a variant of [VerifyDateToTimestamp], see
+// [VerifyTimestampToDatePerCase].
+func VerifyDateToTimestampPerCase(ctx context.Context, parallelism int, ranges [][2]string, values []string, matches map[[3]string]struct{}, convert func(i int) DateToTimestamp) *Report {
+	return verifyCases(ctx, parallelism, `DateToTimestamp`, ranges, values, func(i int, r [2]string, value string) CaseResult {
+		return checkDateToTimestampCase(r, value, matches, convert(i))
+	}, nil)
+}
+
+// ReplayTimestampToDate checks each of cases using
+// [CheckFuzzTimestampToDate], i.e. the same checks as [FuzzTimestampToDate],
+// e.g. to replay a fuzz corpus against an external implementation. The
+// names are used as the case names, and must correspond to cases. See
+// [VerifyTimestampToDate] for the behavior of parallelism.
+func ReplayTimestampToDate(ctx context.Context, parallelism int, names []string, cases []TimestampToDateCase, convert TimestampToDate) *Report {
+	return ReplayTimestampToDatePerCase(ctx, parallelism, names, cases, func(int) TimestampToDate { return convert })
+}
+
+// ReplayTimestampToDatePerCase is a variant of [ReplayTimestampToDate], see
+// [VerifyTimestampToDatePerCase].
+func ReplayTimestampToDatePerCase(ctx context.Context, parallelism int, names []string, cases []TimestampToDateCase, convert func(i int) TimestampToDate) *Report {
+	report := Report{Name: `ReplayTimestampToDate`}
+	start := time.Now()
+	results := make([]CaseResult, len(cases))
+	done := runParallel(ctx, parallelism, len(cases), func(i int) {
+		c := cases[i]
+		result := CaseResult{
+			Name:  names[i],
+			Range: [2]string{formatOptionalTimestamp(c.StartTime), formatOptionalTimestamp(c.EndTime)},
+			Value: c.Value,
+		}
+		convert := convert(i)
+		caseStart := time.Now()
+		func() {
+			defer recoverCase(&result)
+			result.Expected = OracleMatchesDate(c.StartTime, c.EndTime, c.Value)
+			result.Err = CheckFuzzTimestampToDate(c, func(startTime, endTime time.Time) (string, string) {
+				startDate, endDate := convert(startTime, endTime)
+				result.Converted = [2]string{startDate, endDate}
+				return startDate, endDate
+			})
+			result.Actual = MatchesDate(result.Converted[0], result.Converted[1], c.Value)
+		}()
+		result.Duration = time.Since(caseStart)
+		results[i] = result
+	})
+	for i, v := range results {
+		if done[i] {
+			report.Cases = append(report.Cases, v)
+		}
+	}
+	report.Duration = time.Since(start)
+	report.Err = context.Cause(ctx)
+	shrinkFailures(ctx, &report, convert)
+	return &report
+}
+
+// VerifyContiguity is a variant of [TestContiguityExternal] that returns a
+// [Report]. The Range of each case is the span of the sequence, and the
+// Value is empty. See [VerifyTimestampToDate] for the behavior of
+// parallelism.
+func VerifyContiguity(ctx context.Context, parallelism int, cases []ContiguityCase, convert TimestampToDate) *Report {
+	return VerifyContiguityPerCase(ctx, parallelism, cases, func(int) TimestampToDate { return convert })
+}
+
+// VerifyContiguityPerCase is a variant of [VerifyContiguity], see
+// [VerifyTimestampToDatePerCase].
+func VerifyContiguityPerCase(ctx context.Context, parallelism int, cases []ContiguityCase, convert func(i int) TimestampToDate) *Report {
+	report := Report{Name: `Contiguity`}
+	start := time.Now()
+	results := make([]CaseResult, len(cases))
+	done := runParallel(ctx, parallelism, len(cases), func(i int) {
+		c := cases[i]
+		result := CaseResult{Name: c.String(), Expected: true}
+		if c.Validate() == nil {
+			ranges := c.Ranges()
+			result.Range = [2]string{FormatTimestamp(ranges[0][0]), FormatTimestamp(ranges[len(ranges)-1][1])}
+		}
+		caseStart := time.Now()
+		func() {
+			defer recoverCase(&result)
+			result.Err = CheckContiguity(c, convert(i))
+		}()
+		result.Duration = time.Since(caseStart)
+		result.Actual = result.Err == nil
+		results[i] = result
+	})
+	for i, v := range results {
+		if done[i] {
+			report.Cases = append(report.Cases, v)
+		}
+	}
+	report.Duration = time.Since(start)
+	report.Err = context.Cause(ctx)
+	return &report
+}
+
+// verifyCases runs check for each of [RangeTestCases], with its index, using
+// up to parallelism goroutines, then calls observe (if non-nil) with each
+// result, in order.
+func verifyCases(ctx context.Context, parallelism int, name string, ranges [][2]string, values []string, check func(i int, r [2]string, value string) CaseResult, observe func(v CaseResult)) *Report {
+	type testCase struct {
+		r     [2]string
+		value string
+	}
+	var cases []testCase
+	RangeTestCases(ranges, values, func(r [2]string, value string) bool {
+		cases = append(cases, testCase{r, value})
+		return true
+	})
+	report := Report{Name: name}
+	start := time.Now()
+	results := make([]CaseResult, len(cases))
+	done := runParallel(ctx, parallelism, len(cases), func(i int) {
+		caseStart := time.Now()
+		results[i] = check(i, cases[i].r, cases[i].value)
+		results[i].Duration = time.Since(caseStart)
+	})
+	for i, v := range results {
+		if !done[i] {
+			continue
+		}
+		if observe != nil {
+			observe(v)
+		}
+		report.Cases = append(report.Cases, v)
+	}
+	report.Duration = time.Since(start)
+	report.Err = context.Cause(ctx)
+	return &report
+}
+
+// shrinkFailures populates [CaseResult.Minimal], for up to
+// maxShrinkFailures failed cases, as shrinking may require many conversions.
+// The convert function is called with the index of each case.
+func shrinkFailures(ctx context.Context, report *Report, convert func(i int) TimestampToDate) {
+	var n int
+	for i := range report.Cases {
+		v := &report.Cases[i]
+		if v.Err == nil {
+			continue
+		}
+		if n == maxShrinkFailures || ctx.Err() != nil {
+			break
+		}
+		n++
+		bounds, err := parseTimestampRanges([][2]string{v.Range})
+		if err != nil {
+			continue
+		}
+		v.Minimal = ShrinkTimestampToDate(TimestampToDateCase{bounds[0][0], bounds[0][1], v.Value}, convert(i))
+	}
+}
+
+// runParallel calls f with each index in [0, n), in order, using up to
+// parallelism goroutines (at least one). No further calls are started once
+// ctx is done. The returned slice indicates which indexes were run.
+func runParallel(ctx context.Context, parallelism, n int, f func(i int)) []bool {
+	done := make([]bool, n)
+	var (
+		mu   sync.Mutex
+		next int
+		wg   sync.WaitGroup
+	)
+	for range max(1, min(parallelism, n)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				i := next
+				if i == n || ctx.Err() != nil {
+					mu.Unlock()
+					return
+				}
+				next++
+				mu.Unlock()
+				f(i)
+				done[i] = true
+			}
+		}()
+	}
+	wg.Wait()
+	return done
+}
+
+// WriteReportJSONLines writes each case, of each report, as a JSON object,
+// followed by a summary object, per report. The objects are distinguished
+// by their "type" property, which is either "case" or "summary".
+func WriteReportJSONLines(w io.Writer, reports ...*Report) error {
+	type (
+		caseLine struct {
+			Type       string    `json:"type"`
+			Suite      string    `json:"suite"`
+			Name       string    `json:"name"`
+			Range      [2]string `json:"range"`
+			Value      string    `json:"value,omitempty"`
+			Converted  [2]string `json:"converted"`
+			Expected   bool      `json:"expected"`
+			Actual     bool      `json:"actual"`
+			Passed     bool      `json:"passed"`
+			DurationNS int64     `json:"durationNs"`
+			Error      string    `json:"error,omitempty"`
+			// Minimal and Classification are per [CaseResult.Minimal]
+			Minimal        string   `json:"minimal,omitempty"`
+			Classification string   `json:"classification,omitempty"`
+			Stderr         []string `json:"stderr,omitempty"`
+		}
+		summaryLine struct {
+			Type       string `json:"type"`
+			Suite      string `json:"suite"`
+			Passes     int    `json:"passes"`
+			Failures   int    `json:"failures"`
+			DurationNS int64  `json:"durationNs"`
+			Error      string `json:"error,omitempty"`
+		}
+	)
+	e := json.NewEncoder(w)
+	for _, report := range reports {
+		for _, v := range report.Cases {
+			var minimal, classification string
+			if v.Minimal != nil {
+				minimal, classification = v.Minimal.Minimal.String(), v.Minimal.Classification
+			}
+			if err := e.Encode(caseLine{
+				Type:           `case`,
+				Suite:          report.Name,
+				Name:           v.Name,
+				Range:          v.Range,
+				Value:          v.Value,
+				Converted:      v.Converted,
+				Expected:       v.Expected,
+				Actual:         v.Actual,
+				Passed:         v.Err == nil,
+				DurationNS:     int64(v.Duration),
+				Error:          errorString(v.Err),
+				Minimal:        minimal,
+				Classification: classification,
+				Stderr:         v.Stderr,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := e.Encode(summaryLine{
+			Type:       `summary`,
+			Suite:      report.Name,
+			Passes:     report.Passed(),
+			Failures:   report.Failed(),
+			DurationNS: int64(report.Duration),
+			Error:      errorString(report.Err),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteReportJUnit writes the reports as JUnit XML, with one testsuite per
+// report. If a report is incomplete, its [Report.Err] is reported as an
+// error, of an additional testcase.
+func WriteReportJUnit(w io.Writer, reports ...*Report) error {
+	type (
+		message struct {
+			Message string `xml:"message,attr"`
+			Text    string `xml:",chardata"`
+		}
+		testCase struct {
+			Name      string   `xml:"name,attr"`
+			ClassName string   `xml:"classname,attr"`
+			Time      string   `xml:"time,attr"`
+			Failure   *message `xml:"failure,omitempty"`
+			Error     *message `xml:"error,omitempty"`
+			SystemOut string   `xml:"system-out,omitempty"`
+			SystemErr string   `xml:"system-err,omitempty"`
+		}
+		testSuite struct {
+			Name      string     `xml:"name,attr"`
+			Tests     int        `xml:"tests,attr"`
+			Failures  int        `xml:"failures,attr"`
+			Errors    int        `xml:"errors,attr"`
+			Time      string     `xml:"time,attr"`
+			TestCases []testCase `xml:"testcase"`
+		}
+		testSuites struct {
+			XMLName    xml.Name    `xml:"testsuites"`
+			TestSuites []testSuite `xml:"testsuite"`
+		}
+	)
+	seconds := func(d time.Duration) string {
+		return fmt.Sprintf(`%.6f`, d.Seconds())
+	}
+	var doc testSuites
+	for _, report := range reports {
+		suite := testSuite{
+			Name:     report.Name,
+			Tests:    len(report.Cases),
+			Failures: report.Failed(),
+			Time:     seconds(report.Duration),
+		}
+		for _, v := range report.Cases {
+			c := testCase{
+				Name:      v.Name,
+				ClassName: report.Name,
+				Time:      seconds(v.Duration),
+			}
+			if v.Value != `` {
+				c.SystemOut = fmt.Sprintf("range: [%s, %s]\nconverted: [%s, %s]\nvalue: %s\nexpected: %t\nactual: %t\n", v.Range[0], v.Range[1], v.Converted[0], v.Converted[1], v.Value, v.Expected, v.Actual)
+			}
+			for _, line := range v.Stderr {
+				c.SystemErr += line + "\n"
+			}
+			if v.Err != nil {
+				c.Failure = &message{Message: v.String(), Text: v.Err.Error()}
+				if v.Minimal != nil {
+					c.Failure.Text += "\n" + v.Minimal.String()
+				}
+			}
+			suite.TestCases = append(suite.TestCases, c)
+		}
+		if report.Err != nil {
+			suite.Tests++
+			suite.Errors++
+			suite.TestCases = append(suite.TestCases, testCase{
+				Name:      `incomplete`,
+				ClassName: report.Name,
+				Time:      seconds(0),
+				Error:     &message{Message: report.Err.Error(), Text: report.Err.Error()},
+			})
+		}
+		doc.TestSuites = append(doc.TestSuites, suite)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent(``, `  `)
+	if err := e.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteReportSummary writes a human-readable table, with the counts per
+// report, followed by the failures, if any.
+func WriteReportSummary(w io.Writer, reports ...*Report) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "SUITE\tPASSED\tFAILED\tDURATION\tSTATUS")
+	for _, report := range reports {
+		status := `ok`
+		switch {
+		case report.Err != nil:
+			status = `incomplete`
+		case report.Failed() != 0:
+			status = `FAIL`
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n", report.Name, report.Passed(), report.Failed(), report.Duration.Round(time.Microsecond), status)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, report := range reports {
+		for _, v := range report.Cases {
+			if v.Err != nil {
+				if _, err := fmt.Fprintf(w, "\n--- FAIL: %s: %s\n", report.Name, v); err != nil {
+					return err
+				}
+				if v.Minimal != nil {
+					if _, err := fmt.Fprintf(w, "    %s\n", v.Minimal); err != nil {
+						return err
+					}
+				}
+				for _, line := range v.Stderr {
+					if _, err := fmt.Fprintf(w, "    stderr: %s\n", line); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if report.Err != nil {
+			if _, err := fmt.Fprintf(w, "\n--- ERROR: %s: %v\n", report.Name, report.Err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ``
+	}
+	return err.Error()
+}
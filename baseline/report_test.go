@@ -0,0 +1,265 @@
+package baseline
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// exclusiveDateToTimestamp treats the end date as exclusive, which is wrong.
+func exclusiveDateToTimestamp(startDate, endDate string) (startTime, endTime time.Time) {
+	startTime, endTime = ExampleDateToTimestamp(startDate, endDate)
+	if endTime != (time.Time{}) {
+		endTime = endTime.Add(-oneDay)
+	}
+	return
+}
+
+func TestVerifyTimestampToDate(t *testing.T) {
+	report := VerifyTimestampToDate(context.Background(), 1, TimestampRangeValues, DateValues, ExampleMatches, ExampleTimestampToDate)
+	if report.Name != `TimestampToDate` || report.Failed() != 0 || report.Passed() != len(report.Cases) || report.Passed() == 0 || report.Failure() != nil {
+		t.Fatalf("unexpected report: %s passed=%d failed=%d err=%v", report.Name, report.Passed(), report.Failed(), report.Failure())
+	}
+	for _, v := range report.Cases {
+		if v.Duration <= 0 || v.Name == `` || v.Value == `` {
+			t.Fatalf("unexpected case: %+v", v)
+		}
+	}
+}
+
+func TestVerifyDateToTimestamp_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cause := errors.New(`some cause`)
+	var calls int
+	report := VerifyDateToTimestamp(ctx, 1, DateRangeValues, TimestampValues, ExampleMatches, func(startDate, endDate string) (time.Time, time.Time) {
+		if calls++; calls == 3 {
+			cancel(cause)
+		}
+		return ExampleDateToTimestamp(startDate, endDate)
+	})
+	if len(report.Cases) != 3 || report.Err != cause || !errors.Is(report.Failure(), cause) {
+		t.Fatalf("unexpected report: %d cases, err=%v", len(report.Cases), report.Err)
+	}
+}
+
+func TestVerifyTimestampToDate_parallel(t *testing.T) {
+	convert := func(startTime, endTime time.Time) (string, string) {
+		startDate, endDate := ExampleTimestampToDate(startTime, endTime)
+		if startDate != `` {
+			startDate = FormatDate(mustParseDate(startDate).AddDate(0, 0, 1))
+		}
+		return startDate, endDate
+	}
+	expected := VerifyTimestampToDate(context.Background(), 1, TimestampRangeValues, DateValues, ExampleMatches, convert)
+	actual := VerifyTimestampToDate(context.Background(), 8, TimestampRangeValues, DateValues, ExampleMatches, convert)
+	if expected.Failed() == 0 || len(actual.Cases) != len(expected.Cases) {
+		t.Fatalf("unexpected reports: %d cases, %d failed", len(actual.Cases), expected.Failed())
+	}
+	for i := range expected.Cases {
+		a, b := expected.Cases[i], actual.Cases[i]
+		if a.String() != b.String() || a.Converted != b.Converted || a.Actual != b.Actual {
+			t.Fatalf("case %d: expected %s, got %s", i, a, b)
+		}
+	}
+}
+
+func TestVerifyTimestampToDatePerCase(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls = make(map[int][2]time.Time)
+	)
+	report := VerifyTimestampToDatePerCase(context.Background(), 8, TimestampRangeValues, DateValues, ExampleMatches, func(i int) TimestampToDate {
+		return func(startTime, endTime time.Time) (string, string) {
+			mu.Lock()
+			if _, ok := calls[i]; !ok {
+				calls[i] = [2]time.Time{startTime, endTime}
+			}
+			mu.Unlock()
+			return ExampleTimestampToDate(startTime, endTime)
+		}
+	})
+	if len(report.Cases) == 0 || len(calls) != len(report.Cases) {
+		t.Fatalf("unexpected report: %d cases, %d calls", len(report.Cases), len(calls))
+	}
+	for i, v := range report.Cases {
+		bounds, err := parseTimestampRanges([][2]string{v.Range})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if call := calls[i]; !call[0].Equal(bounds[0][0]) || !call[1].Equal(bounds[0][1]) {
+			t.Fatalf("case %d: %s: unexpected call: %v", i, v, call)
+		}
+	}
+}
+
+func TestVerifyTimestampToDate_shrink(t *testing.T) {
+	report := VerifyTimestampToDate(context.Background(), 1, TimestampRangeValues, DateValues, ExampleMatches, func(startTime, endTime time.Time) (startDate, endDate string) {
+		startDate, endDate = ExampleTimestampToDate(startTime, endTime)
+		if endTime != (time.Time{}) {
+			endDate = FormatDate(endTime.UTC())
+		}
+		return
+	})
+	var shrunk int
+	for _, v := range report.Cases {
+		if v.Minimal == nil {
+			continue
+		}
+		if shrunk++; v.Err == nil || v.Minimal.Classification != `end bound off by one day (too late)` {
+			t.Fatalf("unexpected case: %s: %s", v, v.Minimal)
+		}
+	}
+	if report.Failed() <= maxShrinkFailures || shrunk != maxShrinkFailures {
+		t.Fatalf("unexpected report: %d failed, %d shrunk", report.Failed(), shrunk)
+	}
+	var b bytes.Buffer
+	if err := WriteReportSummary(&b, report); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(b.String(), "\n    minimal case [unset, 2000-01-01T00:00:00Z) matching 2000-01-01 (end bound off by one day (too late), "); n != maxShrinkFailures {
+		t.Fatalf("unexpected summary (%d minimal cases):\n%s", n, b.String())
+	}
+}
+
+func TestVerifyContiguity_parallelCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int64
+	report := VerifyContiguity(ctx, 4, ContiguityCases(1, 1000), func(startTime, endTime time.Time) (string, string) {
+		if calls.Add(1) == 50 {
+			cancel()
+		}
+		return ExampleTimestampToDate(startTime, endTime)
+	})
+	if report.Err != context.Canceled || len(report.Cases) == 0 || len(report.Cases) >= 1000 || report.Failed() != 0 {
+		t.Fatalf("unexpected report: %d cases, %d failed, err=%v", len(report.Cases), report.Failed(), report.Err)
+	}
+}
+
+func testReports(t *testing.T) []*Report {
+	reports := []*Report{
+		VerifyDateToTimestamp(context.Background(), 1, DateRangeValues, TimestampValues, ExampleMatches, exclusiveDateToTimestamp),
+		VerifyContiguity(context.Background(), 1, ContiguityCases(1, 5), ExampleTimestampToDate),
+	}
+	if reports[0].Failed() == 0 || reports[1].Failed() != 0 {
+		t.Fatal("unexpected reports")
+	}
+	return reports
+}
+
+func TestWriteReportJSONLines(t *testing.T) {
+	reports := testReports(t)
+	var b bytes.Buffer
+	if err := WriteReportJSONLines(&b, reports...); err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int)
+	var failed int
+	s := bufio.NewScanner(&b)
+	for s.Scan() {
+		var v struct {
+			Type     string `json:"type"`
+			Suite    string `json:"suite"`
+			Passed   *bool  `json:"passed"`
+			Failures int    `json:"failures"`
+		}
+		if err := json.Unmarshal(s.Bytes(), &v); err != nil {
+			t.Fatal(err)
+		}
+		counts[v.Type+`:`+v.Suite]++
+		if v.Type == `summary` {
+			failed += v.Failures
+		}
+	}
+	if counts[`case:DateToTimestamp`] != len(reports[0].Cases) || counts[`case:Contiguity`] != 5 || counts[`summary:DateToTimestamp`] != 1 || counts[`summary:Contiguity`] != 1 {
+		t.Error(counts)
+	}
+	if failed != reports[0].Failed() {
+		t.Error(failed)
+	}
+}
+
+func TestWriteReportJUnit(t *testing.T) {
+	reports := testReports(t)
+	reports[1].Err = errors.New(`incomplete`)
+	var b bytes.Buffer
+	if err := WriteReportJUnit(&b, reports...); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		TestSuites []struct {
+			Name      string `xml:"name,attr"`
+			Tests     int    `xml:"tests,attr"`
+			Failures  int    `xml:"failures,attr"`
+			Errors    int    `xml:"errors,attr"`
+			TestCases []struct {
+				Failure *struct{} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.TestSuites) != 2 {
+		t.Fatal(doc)
+	}
+	if v := doc.TestSuites[0]; v.Name != `DateToTimestamp` || v.Tests != len(reports[0].Cases) || v.Failures != reports[0].Failed() || v.Errors != 0 {
+		t.Errorf("%+v", v)
+	}
+	var failures int
+	for _, v := range doc.TestSuites[0].TestCases {
+		if v.Failure != nil {
+			failures++
+		}
+	}
+	if failures != reports[0].Failed() {
+		t.Error(failures)
+	}
+	if v := doc.TestSuites[1]; v.Tests != 6 || v.Errors != 1 {
+		t.Errorf("%+v", v)
+	}
+}
+
+func TestWriteReportSummary(t *testing.T) {
+	reports := testReports(t)
+	for i, v := range reports[0].Cases {
+		if v.Err != nil {
+			reports[0].Cases[i].Stderr = []string{`debug output`}
+			break
+		}
+	}
+	var b bytes.Buffer
+	if err := WriteReportSummary(&b, reports...); err != nil {
+		t.Fatal(err)
+	}
+	s := b.String()
+	if !strings.HasPrefix(s, "SUITE") || strings.Count(s, `--- FAIL: DateToTimestamp: `) != reports[0].Failed() || !strings.Contains(s, "Contiguity  ") ||
+		strings.Count(s, "\n    stderr: debug output\n") != 1 {
+		t.Error(s)
+	}
+}
+
+func TestReplayTimestampToDate(t *testing.T) {
+	names, cases := ExampleFixtures().TimestampToDateCases()
+	report := ReplayTimestampToDate(context.Background(), 4, names, cases, ExampleTimestampToDate)
+	if report.Failure() != nil || len(report.Cases) != len(cases) {
+		t.Fatalf("unexpected report: %d cases, err=%v", len(report.Cases), report.Failure())
+	}
+	for i, v := range report.Cases {
+		if v.Name != names[i] || v.Value != cases[i].Value || v.Actual != v.Expected || v.Converted[0] == `` && v.Converted[1] == `` {
+			t.Fatalf("unexpected case: %+v", v)
+		}
+	}
+
+	report = ReplayTimestampToDate(context.Background(), 1, names, cases, inclusiveEndTimestampToDate)
+	if report.Failed() == 0 || report.Cases[0].Minimal == nil {
+		t.Fatalf("unexpected report: %d failed", report.Failed())
+	}
+}
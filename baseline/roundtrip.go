@@ -0,0 +1,233 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// CheckTimestampRoundTrip converts the timestamp range [startTime, endTime)
+// to dates, using t2d, and back to timestamps, using d2t, returning an error
+// describing every way in which the result is not the largest range of whole
+// (UTC) days, contained by the original range. Per the README, each set
+// bound must map to the nearest UTC midnight, within the original range, and
+// unset bounds must remain unset. If the date range is empty, no whole day
+// may fit within the original range.
+func CheckTimestampRoundTrip(t2d TimestampToDate, d2t DateToTimestamp, startTime, endTime time.Time) error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	startDate, endDate := t2d(startTime, endTime)
+	for _, v := range [...]struct {
+		name  string
+		input time.Time
+		date  string
+	}{
+		{`startDate`, startTime, startDate},
+		{`endDate`, endTime, endDate},
+	} {
+		if v.input == (time.Time{}) {
+			if v.date != `` {
+				fail(`%s: unset converted to %q`, v.name, v.date)
+			}
+		} else if err := ValidateDate(v.date); err != nil {
+			fail(`%s %q: %w`, v.name, v.date, err)
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf(`round trip [%s, %s): %w`, formatBound(startTime), formatBound(endTime), errors.Join(errs...))
+	}
+
+	roundStart, roundEnd := d2t(startDate, endDate)
+	for _, b := range [...]struct {
+		name          string
+		input, output time.Time
+		// sign is the direction the bound may move, 1 for start, -1 for end
+		sign time.Duration
+	}{
+		{`start`, startTime, roundStart, 1},
+		{`end`, endTime, roundEnd, -1},
+	} {
+		if b.input == (time.Time{}) {
+			if b.output != (time.Time{}) {
+				fail(`%s: unset round tripped to %s`, b.name, FormatTimestamp(b.output))
+			}
+			continue
+		}
+		if b.output == (time.Time{}) {
+			fail(`%s: %s round tripped to unset`, b.name, FormatTimestamp(b.input))
+			continue
+		}
+		if !WidenStartTime(b.output).Equal(b.output) {
+			fail(`%s: %s round tripped to %s, which is not the start of a UTC day`, b.name, FormatTimestamp(b.input), FormatTimestamp(b.output))
+		}
+		if d := b.output.Sub(b.input) * b.sign; d < 0 {
+			fail(`%s: %s round tripped to %s, which is outside the original range`, b.name, FormatTimestamp(b.input), FormatTimestamp(b.output))
+		} else if d >= oneDay {
+			fail(`%s: %s round tripped to %s, which excludes a whole day of the original range`, b.name, FormatTimestamp(b.input), FormatTimestamp(b.output))
+		}
+	}
+
+	if c, _ := compareDates(startDate, endDate); c > 0 {
+		// empty date range - is there any whole day in the original range?
+		if first := WidenEndTime(startTime.UTC()); !first.Add(oneDay).After(endTime) {
+			fail(`empty date range [%s, %s], but %s fits`, startDate, endDate, FormatDate(first))
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf(`round trip [%s, %s) -> [%s, %s] -> [%s, %s): %w`, formatBound(startTime), formatBound(endTime), startDate, endDate, formatBound(roundStart), formatBound(roundEnd), errors.Join(errs...))
+	}
+	return nil
+}
+
+// CheckDateRoundTrip converts the date range [startDate, endDate] to
+// timestamps, using d2t, and back to dates, using t2d, returning an error if
+// the result is not identical. Empty date ranges (the start after the end)
+// have no canonical representation, and are therefore only checked for
+// remaining empty.
+func CheckDateRoundTrip(t2d TimestampToDate, d2t DateToTimestamp, startDate, endDate string) error {
+	empty, err := compareDates(startDate, endDate)
+	if err != nil {
+		return err
+	}
+	startTime, endTime := d2t(startDate, endDate)
+	roundStart, roundEnd := t2d(startTime, endTime)
+	if empty > 0 {
+		if c, err := compareDates(roundStart, roundEnd); err != nil || c <= 0 {
+			return fmt.Errorf(`round trip [%s, %s] -> [%s, %s) -> [%s, %s]: expected an empty date range`, startDate, endDate, formatBound(startTime), formatBound(endTime), roundStart, roundEnd)
+		}
+		return nil
+	}
+	if roundStart != startDate || roundEnd != endDate {
+		return fmt.Errorf(`round trip [%s, %s] -> [%s, %s) -> [%s, %s]: expected the original date range`, startDate, endDate, formatBound(startTime), formatBound(endTime), roundStart, roundEnd)
+	}
+	return nil
+}
+
+// TestRoundTrip may be used to test that a pair of [TimestampToDate] and
+// [DateToTimestamp] implementations agree with each other, using
+// [CheckTimestampRoundTrip], for each of the timestampRanges, and
+// [CheckDateRoundTrip], for each of the dateRanges, including the variants
+// where one bound is unset.
+func TestRoundTrip(t *testing.T, timestampRanges, dateRanges [][2]string, t2d TimestampToDate, d2t DateToTimestamp) {
+	timestampCases, err := parseTimestampRanges(rangeVariants(timestampRanges))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range timestampCases {
+		t.Run(fmt.Sprintf(`%s_%s`, formatBound(r[0]), formatBound(r[1])), func(t *testing.T) {
+			if err := CheckTimestampRoundTrip(t2d, d2t, r[0], r[1]); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	for _, r := range rangeVariants(dateRanges) {
+		t.Run(fmt.Sprintf(`%s_%s`, r[0], r[1]), func(t *testing.T) {
+			if err := CheckDateRoundTrip(t2d, d2t, r[0], r[1]); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestRoundTripExternal is a variant of [TestRoundTrip] that does not require
+// a testing.T instance, returning the combined errors.
+func TestRoundTripExternal(ctx context.Context, timestampRanges, dateRanges [][2]string, t2d TimestampToDate, d2t DateToTimestamp) error {
+	timestampCases, err := parseTimestampRanges(rangeVariants(timestampRanges))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, r := range timestampCases {
+		if err := context.Cause(ctx); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if err := CheckTimestampRoundTrip(t2d, d2t, r[0], r[1]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, r := range rangeVariants(dateRanges) {
+		if err := context.Cause(ctx); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if err := CheckDateRoundTrip(t2d, d2t, r[0], r[1]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FuzzRoundTrip may be used to fuzz test a pair of [TimestampToDate] and
+// [DateToTimestamp] implementations, using [CheckTimestampRoundTrip] and
+// [CheckDateRoundTrip].
+func FuzzRoundTrip(f *testing.F, t2d TimestampToDate, d2t DateToTimestamp) {
+	for _, r := range TimestampRangeValues {
+		startTime, _ := ParseTimestamp(r[0])
+		endTime, _ := ParseTimestamp(r[1])
+		_, startOffset := startTime.Zone()
+		_, endOffset := endTime.Zone()
+		f.Add(startTime.UnixNano(), endTime.UnixNano(), startOffset, endOffset, int32(startTime.Unix()/86400), int32(endTime.Unix()/86400), false, false)
+		f.Add(startTime.UnixNano(), startTime.UnixNano()+int64(oneDay), startOffset, endOffset, int32(endTime.Unix()/86400), int32(startTime.Unix()/86400), false, false)
+		f.Add(startTime.UnixNano(), endTime.UnixNano(), startOffset, endOffset, int32(startTime.Unix()/86400), int32(endTime.Unix()/86400), false, true)
+	}
+	f.Fuzz(func(t *testing.T, startEpoch, endEpoch int64, startOffset, endOffset int, startDateEpoch, endDateEpoch int32, ignoreStart, ignoreEnd bool) {
+		var startTime, endTime time.Time
+		var startDate, endDate string
+		if !ignoreStart {
+			// normalise to the nearest minute, within a day (see FuzzTimestampToDate)
+			startTime = time.Unix(0, startEpoch).In(time.FixedZone(``, startOffset%(24*60*60)/60*60))
+			startDate = EpochDate(startDateEpoch)
+		}
+		if !ignoreEnd {
+			endTime = time.Unix(0, endEpoch).In(time.FixedZone(``, endOffset%(24*60*60)/60*60))
+			endDate = EpochDate(endDateEpoch)
+		}
+		if err := CheckTimestampRoundTrip(t2d, d2t, startTime, endTime); err != nil {
+			t.Fatal(err)
+		}
+		if err := CheckDateRoundTrip(t2d, d2t, startDate, endDate); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// compareDates compares two dates, returning 0 if either is unset, or an
+// error if either is invalid.
+func compareDates(a, b string) (int, error) {
+	if a == `` || b == `` {
+		return 0, nil
+	}
+	x, err := ParseDate(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := ParseDate(b)
+	if err != nil {
+		return 0, err
+	}
+	return x.Compare(y), nil
+}
+
+// parseTimestampRanges parses timestamp ranges, where empty strings are
+// unset (the zero value).
+func parseTimestampRanges(ranges [][2]string) ([][2]time.Time, error) {
+	result := make([][2]time.Time, len(ranges))
+	for i, r := range ranges {
+		for j, s := range r {
+			if s == `` {
+				continue
+			}
+			v, err := ParseTimestamp(s)
+			if err != nil {
+				return nil, err
+			}
+			result[i][j] = v
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package baseline
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExampleTimestampToDate_roundTrip(t *testing.T) {
+	TestRoundTrip(t, TimestampRangeValues, DateRangeValues, ExampleTimestampToDate, ExampleDateToTimestamp)
+}
+
+func TestTestRoundTripExternal_yoDawg(t *testing.T) {
+	if err := TestRoundTripExternal(context.Background(), TimestampRangeValues, DateRangeValues, ExampleTimestampToDate, ExampleDateToTimestamp); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckTimestampRoundTrip_mismatch(t *testing.T) {
+	// treats the end date as exclusive, which disagrees with ExampleTimestampToDate
+	exclusive := func(startDate, endDate string) (startTime, endTime time.Time) {
+		startTime, endTime = ExampleDateToTimestamp(startDate, endDate)
+		if endTime != (time.Time{}) {
+			endTime = endTime.Add(-oneDay)
+		}
+		return
+	}
+	startTime := time.Date(2024, 7, 16, 15, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, 7, 19, 15, 0, 0, 0, time.UTC)
+	err := CheckTimestampRoundTrip(ExampleTimestampToDate, exclusive, startTime, endTime)
+	if err == nil || !strings.Contains(err.Error(), `excludes a whole day`) {
+		t.Fatal(err)
+	}
+	err = CheckDateRoundTrip(ExampleTimestampToDate, exclusive, `2024-07-17`, `2024-07-18`)
+	if err == nil || !strings.Contains(err.Error(), `expected the original date range`) {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckTimestampRoundTrip_local(t *testing.T) {
+	// the naive "local date" implementation, per the README
+	local := func(startTime, endTime time.Time) (startDate, endDate string) {
+		if startTime != (time.Time{}) {
+			startDate = FormatDate(startTime)
+		}
+		if endTime != (time.Time{}) {
+			endDate = FormatDate(endTime.Add(-oneDay))
+		}
+		return
+	}
+	zone := time.FixedZone(``, 36000)
+	startTime := time.Date(2024, 7, 16, 0, 0, 0, 0, zone)
+	endTime := time.Date(2024, 7, 17, 0, 0, 0, 0, zone)
+	err := CheckTimestampRoundTrip(local, ExampleDateToTimestamp, startTime, endTime)
+	if err == nil || !strings.Contains(err.Error(), `outside the original range`) {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckTimestampRoundTrip_empty(t *testing.T) {
+	// a whole day fits, but the range is (incorrectly) empty
+	empty := func(startTime, endTime time.Time) (startDate, endDate string) {
+		startDate, endDate = ExampleTimestampToDate(startTime, endTime)
+		if startDate != `` && endDate != `` {
+			startDate = FormatDate(mustParseDate(endDate).Add(oneDay))
+		}
+		return
+	}
+	startTime := time.Date(2024, 7, 16, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, 7, 17, 0, 0, 0, 0, time.UTC)
+	err := CheckTimestampRoundTrip(empty, ExampleDateToTimestamp, startTime, endTime)
+	if err == nil || !strings.Contains(err.Error(), `but 2024-07-16 fits`) {
+		t.Fatal(err)
+	}
+	// no whole day fits
+	if err := CheckTimestampRoundTrip(ExampleTimestampToDate, ExampleDateToTimestamp, startTime.Add(time.Hour), endTime.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckDateRoundTrip(ExampleTimestampToDate, ExampleDateToTimestamp, `2024-07-18`, `2024-07-16`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func FuzzExampleTimestampToDate_roundTrip(f *testing.F) {
+	FuzzRoundTrip(f, ExampleTimestampToDate, ExampleDateToTimestamp)
+}
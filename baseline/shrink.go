@@ -0,0 +1,301 @@
+package baseline
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	// maxShrinkSteps bounds the number of simplifications made by
+	// [ShrinkTimestampToDate], as a safeguard.
+	maxShrinkSteps = 1000
+
+	// maxShrinkFailures bounds the number of failures shrunk per [Report].
+	maxShrinkFailures = 10
+)
+
+type (
+	// TimestampToDateCase is a single input, as generated by
+	// [FuzzTimestampToDate], i.e. a timestamp range, with zero values for
+	// unset bounds, and a date value, to be matched against the converted
+	// range.
+	TimestampToDateCase struct {
+		StartTime, EndTime time.Time
+		Value              string
+	}
+
+	// ShrinkResult is the outcome of [ShrinkTimestampToDate].
+	ShrinkResult struct {
+		Original, Minimal TimestampToDateCase
+		// Classification describes the failure, of both the original and
+		// minimal cases, e.g. "end bound off by one day (too early)".
+		Classification string
+		// Err is the failure of the minimal case.
+		Err error
+		// Steps is the number of simplifications that were made.
+		Steps int
+	}
+)
+
+// String formats the case in RFC 3339 form, e.g.
+// "[2024-01-01T00:00:00Z, 2024-02-01T00:00:00Z) matching 2024-01-31".
+func (x TimestampToDateCase) String() string {
+	return fmt.Sprintf(`[%s, %s) matching %s`, formatBound(x.StartTime), formatBound(x.EndTime), x.Value)
+}
+
+// Validate checks that x is within the domain of [FuzzTimestampToDate],
+// i.e. at least one bound is set, and, if both are set, the range spans at
+// least one day.
+func (x TimestampToDateCase) Validate() error {
+	startSet, endSet := x.StartTime != (time.Time{}), x.EndTime != (time.Time{})
+	if !startSet && !endSet {
+		return errors.New(`shrink: at least one bound must be set`)
+	}
+	if startSet && endSet && x.EndTime.Sub(x.StartTime) < oneDay {
+		return errors.New(`shrink: range must span at least one day`)
+	}
+	return ValidateDate(x.Value)
+}
+
+func (x *ShrinkResult) String() string {
+	return fmt.Sprintf(`minimal case %s (%s, after %d steps): %v`, x.Minimal, x.Classification, x.Steps, x.Err)
+}
+
+// CheckTimestampToDateCase converts the range of c, returning an error if
+// the result differs from [ExampleTimestampToDate], or if matching the value
+// differs from [OracleMatchesDate]. Panics are recovered, and returned as
+// errors.
+func CheckTimestampToDateCase(c TimestampToDateCase, convert TimestampToDate) error {
+	startDate, endDate, err := convertTimestampToDateCase(c, convert)
+	if err != nil {
+		return err
+	}
+	if a, b := ExampleTimestampToDate(c.StartTime, c.EndTime); a != startDate || b != endDate {
+		return fmt.Errorf(`differed from baseline for %s: expected [%s, %s], got [%s, %s]`, c, a, b, startDate, endDate)
+	}
+	if expected, actual := OracleMatchesDate(c.StartTime, c.EndTime, c.Value), MatchesDate(startDate, endDate, c.Value); expected != actual {
+		return fmt.Errorf(`expected %t, got %t: %s -> [%s, %s]`, expected, actual, c, startDate, endDate)
+	}
+	return nil
+}
+
+// ShrinkTimestampToDate simplifies a failing case, per
+// [CheckTimestampToDateCase], while it continues to fail in the same way,
+// i.e. with the same classification. Simplifications include unsetting
+// bounds, moving bounds to offset 0, rounding away (sub-)seconds, minutes
+// and hours, shortening the range, towards the value, and shifting the whole
+// case, such that the value is a nearby "simple" date. It returns nil if c
+// is invalid, or does not fail. The convert function is called many times.
+func ShrinkTimestampToDate(c TimestampToDateCase, convert TimestampToDate) *ShrinkResult {
+	if c.Validate() != nil {
+		return nil
+	}
+	err := CheckTimestampToDateCase(c, convert)
+	if err == nil {
+		return nil
+	}
+	result := ShrinkResult{
+		Original:       c,
+		Minimal:        c,
+		Classification: classifyTimestampToDateCase(c, convert),
+		Err:            err,
+	}
+	for result.Steps < maxShrinkSteps {
+		complexity := shrinkComplexity(result.Minimal)
+		var accepted bool
+		for _, candidate := range shrinkCandidates(result.Minimal) {
+			if candidate.Validate() != nil ||
+				slices.Compare(shrinkComplexity(candidate), complexity) >= 0 {
+				continue
+			}
+			err := CheckTimestampToDateCase(candidate, convert)
+			if err == nil || classifyTimestampToDateCase(candidate, convert) != result.Classification {
+				continue
+			}
+			result.Minimal, result.Err = candidate, err
+			result.Steps++
+			accepted = true
+			break
+		}
+		if !accepted {
+			break
+		}
+	}
+	return &result
+}
+
+// classifyTimestampToDateCase describes how the conversion of c differs from
+// [ExampleTimestampToDate], per bound.
+func classifyTimestampToDateCase(c TimestampToDateCase, convert TimestampToDate) string {
+	startDate, endDate, err := convertTimestampToDateCase(c, convert)
+	if err != nil {
+		return `panic`
+	}
+	expectedStart, expectedEnd := ExampleTimestampToDate(c.StartTime, c.EndTime)
+	var s string
+	for _, v := range [...]struct {
+		name             string
+		expected, actual string
+	}{
+		{`start`, expectedStart, startDate},
+		{`end`, expectedEnd, endDate},
+	} {
+		if v.expected == v.actual {
+			continue
+		}
+		if s != `` {
+			s += `, `
+		}
+		s += classifyBound(v.name, v.expected, v.actual)
+	}
+	if s == `` {
+		// the bounds match the baseline, so the failure must be the match
+		s = `value matched incorrectly`
+	}
+	return s
+}
+
+func classifyBound(name, expected, actual string) string {
+	switch {
+	case expected == ``:
+		return name + ` bound should be unset`
+	case actual == ``:
+		return name + ` bound unset`
+	case ValidateDate(actual) != nil:
+		return name + ` bound invalid`
+	}
+	days := int(mustParseDate(actual).Sub(mustParseDate(expected)) / oneDay)
+	direction := `too late`
+	if days < 0 {
+		days, direction = -days, `too early`
+	}
+	if days == 1 {
+		return fmt.Sprintf(`%s bound off by one day (%s)`, name, direction)
+	}
+	return fmt.Sprintf(`%s bound off by %d days (%s)`, name, days, direction)
+}
+
+func convertTimestampToDateCase(c TimestampToDateCase, convert TimestampToDate) (startDate, endDate string, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf(`panic: %v`, v)
+		}
+	}()
+	startDate, endDate = convert(c.StartTime, c.EndTime)
+	return
+}
+
+// shrinkComplexity returns a vector, where a lexicographically smaller
+// vector indicates a simpler case.
+func shrinkComplexity(c TimestampToDateCase) []int {
+	value := mustParseDate(c.Value)
+	v := make([]int, 10)
+	for _, t := range [...]time.Time{c.StartTime, c.EndTime} {
+		if t == (time.Time{}) {
+			continue
+		}
+		v[0]++
+		if _, offset := t.Zone(); offset != 0 {
+			v[1]++
+		}
+		for i, n := range [...]int{t.Nanosecond(), t.Second(), t.Minute(), t.Hour()} {
+			if n != 0 {
+				v[2+i]++
+			}
+		}
+		v[6] += abs(int(WidenStartTime(t).Sub(value) / oneDay))
+	}
+	v[7] = abs(value.Year() - 2000)
+	v[8] = int(value.Month()) - 1
+	v[9] = value.Day() - 1
+	return v
+}
+
+// shrinkCandidates returns possible simplifications of c, which may be
+// invalid, or not actually simpler.
+func shrinkCandidates(c TimestampToDateCase) (candidates []TimestampToDateCase) {
+	value := mustParseDate(c.Value)
+	for i, t := range [...]time.Time{c.StartTime, c.EndTime} {
+		if t == (time.Time{}) {
+			continue
+		}
+		add := func(t time.Time) {
+			candidate := c
+			if i == 0 {
+				candidate.StartTime = t
+			} else {
+				candidate.EndTime = t
+			}
+			candidates = append(candidates, candidate)
+		}
+
+		add(time.Time{})
+
+		// same instant, then same wall clock, at offset 0
+		add(t.UTC())
+		add(time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC))
+
+		// round away components of the wall clock, in either direction
+		for _, v := range [...]struct {
+			t    time.Time
+			unit time.Duration
+		}{
+			{time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, t.Location()), time.Second},
+			{time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location()), time.Minute},
+			{time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()), time.Hour},
+			{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), oneDay},
+		} {
+			add(v.t)
+			add(v.t.Add(v.unit))
+		}
+
+		// shorten the range, moving the bound towards the value
+		if days := int(value.Sub(WidenStartTime(t)) / oneDay); days != 0 {
+			for _, n := range [...]int{days, days / 2, sign(days)} {
+				add(t.Add(time.Duration(n) * oneDay))
+			}
+		}
+	}
+
+	// shift the whole case, such that the value is a simpler date
+	for _, target := range [...]time.Time{
+		time.Date(value.Year(), value.Month(), 1, 0, 0, 0, 0, time.UTC),
+		time.Date(value.Year(), 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2000, value.Month(), value.Day(), 0, 0, 0, 0, time.UTC),
+		time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		d := target.Sub(value)
+		if d == 0 {
+			continue
+		}
+		candidate := c
+		for _, p := range [...]*time.Time{&candidate.StartTime, &candidate.EndTime} {
+			if *p != (time.Time{}) {
+				*p = p.Add(d)
+			}
+		}
+		candidate.Value = FormatDate(target)
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package baseline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShrinkTimestampToDate(t *testing.T) {
+	for _, tc := range [...]struct {
+		name           string
+		convert        TimestampToDate
+		r              [2]string
+		value          string
+		classification string
+		minimal        string
+	}{
+		{
+			name: `inclusive end`,
+			convert: func(startTime, endTime time.Time) (startDate, endDate string) {
+				startDate, endDate = ExampleTimestampToDate(startTime, endTime)
+				if endTime != (time.Time{}) {
+					endDate = FormatDate(endTime.UTC())
+				}
+				return
+			},
+			// from the fuzz corpus (testdata/fuzz/FuzzTimestampToDate/a8b588b93d760eb1)
+			r:              [2]string{`2024-01-01T00:00:00Z`, `2024-01-31T14:59:59.000000078-09:00`},
+			value:          `2024-01-01`,
+			classification: `end bound off by one day (too late)`,
+			minimal:        `[unset, 2000-01-01T00:00:00Z) matching 2000-01-01`,
+		},
+		{
+			name: `local`,
+			convert: func(startTime, endTime time.Time) (string, string) {
+				local := func(t time.Time) time.Time {
+					if t == (time.Time{}) {
+						return t
+					}
+					return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+				}
+				return ExampleTimestampToDate(local(startTime), local(endTime))
+			},
+			r:              [2]string{`2023-07-01T00:00:00.123-07:00`, `2023-07-31T03:00:00+09:00`},
+			value:          `2023-07-19`,
+			classification: `end bound off by one day (too late)`,
+			minimal:        `[unset, 2000-01-02T00:00:00+09:00) matching 2000-01-01`,
+		},
+		{
+			name: `truncated start`,
+			convert: func(startTime, endTime time.Time) (startDate, endDate string) {
+				startDate, endDate = ExampleTimestampToDate(startTime, endTime)
+				if startTime != (time.Time{}) {
+					startDate = FormatDate(startTime.UTC())
+				}
+				return
+			},
+			r:              [2]string{`2024-03-09T13:14:15.999999999-08:00`, `2024-03-11T23:59:59-07:00`},
+			value:          `2024-03-10`,
+			classification: `start bound off by one day (too early)`,
+			minimal:        `[2000-01-01T21:00:00Z, unset) matching 2000-01-01`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bounds := mustParseTimestamps(t, tc.r[:]...)
+			result := ShrinkTimestampToDate(TimestampToDateCase{bounds[0], bounds[1], tc.value}, tc.convert)
+			if result == nil {
+				t.Fatal(`expected failure`)
+			}
+			if result.Classification != tc.classification || result.Minimal.String() != tc.minimal || result.Err == nil {
+				t.Errorf("unexpected result: %s", result)
+			}
+			if err := CheckTimestampToDateCase(result.Minimal, tc.convert); err == nil || err.Error() != result.Err.Error() {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if result := ShrinkTimestampToDate(result.Minimal, ExampleTimestampToDate); result != nil {
+				t.Errorf("unexpected result for the baseline: %s", result)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package baseline
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type (
+	// CaseResult is the outcome of a single case, of [TestTimestampToDate] or
+	// [TestDateToTimestamp], i.e. converting Range, then matching Value
+	// against the Converted range.
+	CaseResult struct {
+		// Name identifies the case, and is used as the subtest name.
+		Name string
+		// Range is the input range, timestamps or dates, per the direction.
+		Range [2]string
+		// Value is matched against the Converted range.
+		Value string
+		// Converted is the output range, formatted as dates or timestamps,
+		// with empty strings for unset bounds.
+		Converted [2]string
+		// Expected and Actual are whether Value matches.
+		Expected, Actual bool
+		// Duration is the time taken to run the case.
+		Duration time.Duration
+		// Err is non-nil if the case failed.
+		Err error
+		// Minimal is the failure simplified by [ShrinkTimestampToDate], if
+		// available (only [VerifyTimestampToDate] populates it).
+		Minimal *ShrinkResult
+		// Stderr is any diagnostic output of an external implementation,
+		// attributed to the case. It is populated by the caller, e.g. the
+		// verify commands, as the conversion functions don't provide it.
+		Stderr []string
+	}
+
+	// VerificationError is returned by [TestTimestampToDateExternal] and
+	// [TestDateToTimestampExternal], if any cases failed.
+	VerificationError struct {
+		Passed, Failed int
+		// Failures are the failed cases, in the order they were run.
+		Failures []CaseResult
+	}
+)
+
+// String describes the case, and the failure, if any. Note that the error
+// will typically describe the converted range.
+func (x CaseResult) String() string {
+	s := x.Name
+	if x.Value != `` {
+		s = fmt.Sprintf(`[%s, %s] matching %s`, x.Range[0], x.Range[1], x.Value)
+	}
+	if x.Err != nil {
+		s += `: ` + x.Err.Error()
+	}
+	return s
+}
+
+// Summary returns the pass/fail counts.
+func (x *VerificationError) Summary() string {
+	return fmt.Sprintf(`%d passed, %d failed, of %d cases`, x.Passed, x.Failed, x.Passed+x.Failed)
+}
+
+func (x *VerificationError) Error() string {
+	var b strings.Builder
+	b.WriteString(`verification failed: `)
+	b.WriteString(x.Summary())
+	for _, v := range x.Failures {
+		b.WriteString("\n\t")
+		b.WriteString(v.String())
+	}
+	return b.String()
+}
+
+// Unwrap returns the errors of the failed cases.
+func (x *VerificationError) Unwrap() []error {
+	errs := make([]error, len(x.Failures))
+	for i, v := range x.Failures {
+		errs[i] = v.Err
+	}
+	return errs
+}
+
+// checkTimestampToDateCase converts the timestamp range r, and matches the
+// date value against the result. Panics (e.g. from a failing external
+// command) are recovered, and reported as the case error.
+func checkTimestampToDateCase(r [2]string, value string, matches map[[3]string]struct{}, convert TimestampToDate) (result CaseResult) {
+	result.Name = r[0] + `-` + r[1] + `-` + value
+	result.Range = r
+	result.Value = value
+	_, result.Expected = matches[[3]string{r[0], r[1], value}]
+	defer recoverCase(&result)
+
+	var bounds [2]time.Time
+	for i, s := range r {
+		if s == `` {
+			continue
+		}
+		var err error
+		if bounds[i], err = ParseTimestamp(s); err != nil {
+			result.Err = fmt.Errorf(`range error: %w`, err)
+			return
+		}
+	}
+
+	if err := ValidateDate(value); err != nil {
+		result.Err = fmt.Errorf(`value error: %w`, err)
+		return
+	}
+
+	startDate, endDate := convert(bounds[0], bounds[1])
+	result.Converted = [2]string{startDate, endDate}
+	if r[0] != `` {
+		if err := ValidateDate(startDate); err != nil {
+			result.Err = fmt.Errorf(`startDate error: %w`, err)
+			return
+		}
+	}
+	if r[1] != `` {
+		if err := ValidateDate(endDate); err != nil {
+			result.Err = fmt.Errorf(`endDate error: %w`, err)
+			return
+		}
+	}
+
+	result.Actual = MatchesDate(startDate, endDate, value)
+	if result.Actual != result.Expected {
+		result.Err = fmt.Errorf(`expected %t, got %t: [%s, %s] matching %s`, result.Expected, result.Actual, startDate, endDate, value)
+	}
+	return
+}
+
+// checkDateToTimestampCase converts the date range r, and matches the
+// timestamp value against the result. Panics are recovered, see
+// [checkTimestampToDateCase].
+func checkDateToTimestampCase(r [2]string, valStr string, matches map[[3]string]struct{}, convert DateToTimestamp) (result CaseResult) {
+	result.Name = r[0] + `-` + r[1] + `-` + valStr
+	result.Range = r
+	result.Value = valStr
+	_, result.Expected = matches[[3]string{r[0], r[1], valStr}]
+	defer recoverCase(&result)
+
+	value, err := ParseTimestamp(valStr)
+	if err != nil {
+		result.Err = fmt.Errorf(`value error: %w`, err)
+		return
+	}
+
+	if r[0] != `` {
+		if err := ValidateDate(r[0]); err != nil {
+			result.Err = fmt.Errorf(`startDate error: %w`, err)
+			return
+		}
+	}
+	if r[1] != `` {
+		if err := ValidateDate(r[1]); err != nil {
+			result.Err = fmt.Errorf(`endDate error: %w`, err)
+			return
+		}
+	}
+
+	startTime, endTime := convert(r[0], r[1])
+	if startTime != (time.Time{}) {
+		result.Converted[0] = FormatTimestamp(startTime)
+	}
+	if endTime != (time.Time{}) {
+		result.Converted[1] = FormatTimestamp(endTime)
+	}
+	if (r[0] == ``) != (startTime == (time.Time{})) {
+		result.Err = fmt.Errorf(`start time zero value mismatch for input: %s`, r[0])
+		return
+	}
+	if (r[1] == ``) != (endTime == (time.Time{})) {
+		result.Err = fmt.Errorf(`end time zero value mismatch for input: %s`, r[1])
+		return
+	}
+
+	result.Actual = MatchesTimestamp(startTime, endTime, value)
+	if result.Actual != result.Expected {
+		result.Err = fmt.Errorf(
+			`expected %t, got %t: [%s, %s] matching %s`,
+			result.Expected,
+			result.Actual,
+			result.Converted[0],
+			result.Converted[1],
+			FormatTimestamp(value),
+		)
+	}
+	return
+}
+
+func recoverCase(result *CaseResult) {
+	if v := recover(); v != nil {
+		err, ok := v.(error)
+		if !ok {
+			err = fmt.Errorf(`%v`, v)
+		}
+		result.Err = errors.Join(result.Err, fmt.Errorf(`panic: %w`, err))
+	}
+}
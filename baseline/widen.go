@@ -0,0 +1,286 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// CheckWiden calls widen for the range [startTime, endTime), and variants of
+// it, returning an error describing every way in which the result does not
+// satisfy the properties of [WidenRange], which are:
+//
+//   - alignment: each set bound is the start of a UTC day
+//   - containment: the widened range contains the original range
+//   - minimality: neither bound moves by a whole day or more
+//   - idempotency: widening the widened range returns it unchanged
+//   - independence: each bound depends only on the instant of the
+//     corresponding input bound, and not its zone, or the other bound
+//   - unset (zero value) bounds remain unset
+//
+// Note that monotonicity is checked separately, see [CheckWidenMonotonic].
+func CheckWiden(widen Widen, startTime, endTime time.Time) error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	wideStart, wideEnd := widen(startTime, endTime)
+
+	for _, b := range [...]struct {
+		name          string
+		input, output time.Time
+		// sign is the direction the bound may move, -1 for start, 1 for end
+		sign time.Duration
+	}{
+		{`start`, startTime, wideStart, -1},
+		{`end`, endTime, wideEnd, 1},
+	} {
+		if b.input == (time.Time{}) {
+			if b.output != (time.Time{}) {
+				fail(`%s: unset widened to %s`, b.name, FormatTimestamp(b.output))
+			}
+			continue
+		}
+		if b.output == (time.Time{}) {
+			fail(`%s: %s widened to unset`, b.name, FormatTimestamp(b.input))
+			continue
+		}
+		if !WidenStartTime(b.output).Equal(b.output) {
+			fail(`%s: %s widened to %s, which is not the start of a UTC day`, b.name, FormatTimestamp(b.input), FormatTimestamp(b.output))
+		}
+		if d := b.output.Sub(b.input) * b.sign; d < 0 {
+			fail(`%s: %s widened to %s, which does not contain it`, b.name, FormatTimestamp(b.input), FormatTimestamp(b.output))
+		} else if d >= oneDay {
+			fail(`%s: %s widened to %s, which moved it by %s`, b.name, FormatTimestamp(b.input), FormatTimestamp(b.output), d)
+		}
+	}
+
+	expectRange := func(name string, start, end time.Time) {
+		if !start.Equal(wideStart) || !end.Equal(wideEnd) || (start == (time.Time{})) != (wideStart == (time.Time{})) || (end == (time.Time{})) != (wideEnd == (time.Time{})) {
+			fail(`%s: expected [%s, %s), got [%s, %s)`, name, formatBound(wideStart), formatBound(wideEnd), formatBound(start), formatBound(end))
+		}
+	}
+
+	// idempotency
+	{
+		start, end := widen(wideStart, wideEnd)
+		expectRange(`idempotency`, start, end)
+	}
+
+	// independence (zone)
+	{
+		utcStart, utcEnd := startTime, endTime
+		if utcStart != (time.Time{}) {
+			utcStart = utcStart.UTC()
+		}
+		if utcEnd != (time.Time{}) {
+			utcEnd = utcEnd.UTC()
+		}
+		if utcStart != startTime || utcEnd != endTime {
+			start, end := widen(utcStart, utcEnd)
+			expectRange(`independence (UTC)`, start, end)
+		}
+	}
+
+	// independence (other bound)
+	if startTime != (time.Time{}) && endTime != (time.Time{}) {
+		start, _ := widen(startTime, time.Time{})
+		_, end := widen(time.Time{}, endTime)
+		expectRange(`independence (unset other bound)`, start, end)
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf(`widen [%s, %s): %w`, formatBound(startTime), formatBound(endTime), errors.Join(errs...))
+	}
+	return nil
+}
+
+// CheckWidenMonotonic calls widen for each of the values, as both the start
+// and end, returning an error if either bound is not monotonic, i.e. a later
+// value must never widen to an earlier bound.
+func CheckWidenMonotonic(widen Widen, values []time.Time) error {
+	type point struct {
+		value, start, end time.Time
+	}
+	points := make([]point, 0, len(values))
+	for _, v := range values {
+		if v == (time.Time{}) {
+			continue
+		}
+		start, end := widen(v, v)
+		points = append(points, point{v, start, end})
+	}
+	slices.SortStableFunc(points, func(a, b point) int {
+		return a.value.Compare(b.value)
+	})
+
+	var errs []error
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		if b.start.Before(a.start) {
+			errs = append(errs, fmt.Errorf(`start: %s widened to %s, but the earlier %s widened to %s`, FormatTimestamp(b.value), FormatTimestamp(b.start), FormatTimestamp(a.value), FormatTimestamp(a.start)))
+		}
+		if b.end.Before(a.end) {
+			errs = append(errs, fmt.Errorf(`end: %s widened to %s, but the earlier %s widened to %s`, FormatTimestamp(b.value), FormatTimestamp(b.end), FormatTimestamp(a.value), FormatTimestamp(a.end)))
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf(`widen monotonicity: %w`, errors.Join(errs...))
+	}
+	return nil
+}
+
+// TestWiden may be used to test a [Widen] implementation, using
+// [CheckWiden], for each of the ranges and values (as a range of one
+// instant), and [CheckWidenMonotonic], for all the bounds and values.
+// Both the ranges and the values are timestamps.
+func TestWiden(t *testing.T, ranges [][2]string, values []string, widen Widen) {
+	cases, points, err := parseWidenCases(ranges, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range cases {
+		t.Run(fmt.Sprintf(`%s_%s`, formatBound(r[0]), formatBound(r[1])), func(t *testing.T) {
+			if err := CheckWiden(widen, r[0], r[1]); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	t.Run(`monotonic`, func(t *testing.T) {
+		if err := CheckWidenMonotonic(widen, points); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// TestWidenExternal is a variant of [TestWiden] that does not require a
+// testing.T instance, returning the combined errors.
+func TestWidenExternal(ctx context.Context, ranges [][2]string, values []string, widen Widen) error {
+	cases, points, err := parseWidenCases(ranges, values)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, r := range cases {
+		if err := context.Cause(ctx); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if err := CheckWiden(widen, r[0], r[1]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := CheckWidenMonotonic(widen, points); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// FuzzWiden may be used to fuzz test a [Widen] implementation, using
+// [CheckWiden] and [CheckWidenMonotonic].
+func FuzzWiden(f *testing.F, ranges [][2]string, widen Widen) {
+	for _, r := range ranges {
+		startTime, err := ParseTimestamp(r[0])
+		if err != nil {
+			f.Fatal(err)
+		}
+		endTime, err := ParseTimestamp(r[1])
+		if err != nil {
+			f.Fatal(err)
+		}
+		_, startOffset := startTime.Zone()
+		_, endOffset := endTime.Zone()
+		f.Add(startTime.UnixNano(), endTime.UnixNano(), startOffset, endOffset, false, false)
+		f.Add(startTime.UnixNano(), endTime.UnixNano(), startOffset, endOffset, true, false)
+		f.Add(startTime.UnixNano(), endTime.UnixNano(), startOffset, endOffset, false, true)
+	}
+	f.Fuzz(func(t *testing.T, startEpoch, endEpoch int64, startOffset, endOffset int, ignoreStart, ignoreEnd bool) {
+		var startTime, endTime time.Time
+		if !ignoreStart {
+			// normalise to the nearest minute, within a day (see FuzzTimestampToDate)
+			startTime = time.Unix(0, startEpoch).In(time.FixedZone(``, startOffset%(24*60*60)/60*60))
+		}
+		if !ignoreEnd {
+			endTime = time.Unix(0, endEpoch).In(time.FixedZone(``, endOffset%(24*60*60)/60*60))
+		}
+		if err := CheckWiden(widen, startTime, endTime); err != nil {
+			t.Fatal(err)
+		}
+		if err := CheckWidenMonotonic(widen, []time.Time{startTime, endTime}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// parseWidenCases parses the ranges, including the variants where one bound
+// is unset, and values (as ranges of one instant), also returning every
+// distinct timestamp, for monotonicity checks.
+func parseWidenCases(ranges [][2]string, values []string) (cases [][2]time.Time, points []time.Time, err error) {
+	parse := func(s string) (time.Time, error) {
+		if s == `` {
+			return time.Time{}, nil
+		}
+		return ParseTimestamp(s)
+	}
+	seen := make(map[[2]string]struct{})
+	add := func(r [2]string) error {
+		if _, ok := seen[r]; ok {
+			return nil
+		}
+		seen[r] = struct{}{}
+		var c [2]time.Time
+		for i, s := range r {
+			v, err := parse(s)
+			if err != nil {
+				return err
+			}
+			c[i] = v
+		}
+		cases = append(cases, c)
+		return nil
+	}
+	for _, r := range ranges {
+		for _, r := range [...][2]string{r, {``, r[1]}, {r[0], ``}} {
+			if err = add(r); err != nil {
+				return
+			}
+		}
+		for _, s := range r {
+			v, err := parse(s)
+			if err != nil {
+				return nil, nil, err
+			}
+			points = append(points, v)
+		}
+	}
+	for _, s := range values {
+		if err = add([2]string{s, s}); err != nil {
+			return
+		}
+		v, _ := parse(s)
+		points = append(points, v)
+	}
+	if err = add([2]string{}); err != nil {
+		return
+	}
+	return
+}
+
+func formatBound(t time.Time) string {
+	if t == (time.Time{}) {
+		return `unset`
+	}
+	return FormatTimestamp(t)
+}
+
+// formatOptionalTimestamp is a variant of [formatBound], that returns an
+// empty string for the zero value, per the test data.
+func formatOptionalTimestamp(t time.Time) string {
+	if t == (time.Time{}) {
+		return ``
+	}
+	return FormatTimestamp(t)
+}
@@ -0,0 +1,129 @@
+package baseline
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWidenRange_properties(t *testing.T) {
+	TestWiden(t, TimestampRangeValues, TimestampValues, WidenRange)
+}
+
+func TestTestWidenExternal_yoDawg(t *testing.T) {
+	if err := TestWidenExternal(context.Background(), TimestampRangeValues, TimestampValues, WidenRange); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckWiden_invalid(t *testing.T) {
+	startTime := time.Date(2024, 7, 16, 15, 0, 0, 0, time.FixedZone(``, 36000))
+	endTime := time.Date(2024, 7, 18, 2, 0, 0, 0, time.FixedZone(``, 36000))
+	for _, tc := range [...]struct {
+		name  string
+		widen Widen
+		err   string
+	}{
+		{
+			// the naive "local date" implementation
+			name: `local`,
+			widen: func(startTime, endTime time.Time) (time.Time, time.Time) {
+				local := func(t time.Time) time.Time {
+					return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+				}
+				if startTime != (time.Time{}) {
+					startTime = local(startTime)
+				}
+				if endTime != (time.Time{}) {
+					endTime = local(endTime).AddDate(0, 0, 1)
+				}
+				return startTime, endTime
+			},
+			err: `not the start of a UTC day`,
+		},
+		{
+			name: `always next day`,
+			widen: func(startTime, endTime time.Time) (time.Time, time.Time) {
+				if endTime != (time.Time{}) {
+					endTime = WidenStartTime(endTime).Add(oneDay)
+				}
+				return WidenStartTime(startTime), endTime
+			},
+			err: `idempotency`,
+		},
+		{
+			name: `too wide`,
+			widen: func(startTime, endTime time.Time) (time.Time, time.Time) {
+				if startTime != (time.Time{}) {
+					startTime = WidenStartTime(startTime).Add(-oneDay)
+				}
+				return startTime, WidenEndTime(endTime)
+			},
+			err: `which moved it by`,
+		},
+		{
+			name: `narrow`,
+			widen: func(startTime, endTime time.Time) (time.Time, time.Time) {
+				if startTime != (time.Time{}) {
+					startTime = WidenEndTime(startTime)
+				}
+				return startTime, WidenEndTime(endTime)
+			},
+			err: `which does not contain it`,
+		},
+		{
+			name: `unset`,
+			widen: func(startTime, endTime time.Time) (time.Time, time.Time) {
+				if startTime == (time.Time{}) {
+					startTime = time.Unix(0, 0)
+				}
+				return WidenRange(startTime, endTime)
+			},
+			err: `unset widened to`,
+		},
+		{
+			name: `dependent`,
+			widen: func(startTime, endTime time.Time) (time.Time, time.Time) {
+				startTime, endTime = WidenRange(startTime, endTime)
+				if endTime.Sub(startTime) == 2*oneDay {
+					endTime = endTime.Add(-oneDay)
+				}
+				return startTime, endTime
+			},
+			err: `independence (unset other bound)`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, e := startTime, endTime
+			if tc.name == `unset` {
+				s = time.Time{}
+			}
+			err := CheckWiden(tc.widen, s, e)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestCheckWidenMonotonic(t *testing.T) {
+	values := mustParseTimestamps(t, "2024-07-16T23:00:00Z", "2024-07-17T01:00:00Z", "2024-07-16T12:00:00Z")
+	if err := CheckWidenMonotonic(WidenRange, values); err != nil {
+		t.Fatal(err)
+	}
+	// widens late starts by an extra day, which is aligned, but not monotonic
+	widen := func(startTime, endTime time.Time) (time.Time, time.Time) {
+		if startTime.Hour() >= 22 {
+			startTime = startTime.Add(-oneDay)
+		}
+		return WidenRange(startTime, endTime)
+	}
+	if err := CheckWidenMonotonic(widen, values); err == nil || !strings.Contains(err.Error(), `start: 2024-07-16T23:00:00Z widened to 2024-07-15T00:00:00Z`) {
+		t.Fatal(err)
+	}
+}
+
+func FuzzWidenRange_properties(f *testing.F) {
+	FuzzWiden(f, TimestampRangeValues, WidenRange)
+}
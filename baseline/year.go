@@ -0,0 +1,196 @@
+package baseline
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ExtendedYearDigits is the minimum number of digits used to represent years
+// outside the range 0000-9999, which are formatted with a leading sign, per
+// the ISO 8601 expanded representation, e.g. +10000-01-01 or -00001-12-31.
+const ExtendedYearDigits = 5
+
+// maxExtendedYearDigits is enough to represent the year of any time.Time
+// that has a valid unix timestamp.
+const maxExtendedYearDigits = 12
+
+// minExtendedYear and maxExtendedYear bound the years that may be parsed,
+// such that every time within them, at any offset, may be represented by a
+// time.Time, with a valid unix timestamp. Years beyond them would overflow.
+// On 32-bit platforms, the range is further limited to that of int.
+const (
+	minExtendedYear int64 = -292277022398
+	maxExtendedYear int64 = 292277026595
+)
+
+// FormatDate formats t using [DateFormat], or the equivalent extended
+// format, if the year is outside of 0000-9999. See [ExtendedYearDigits].
+func FormatDate(t time.Time) string {
+	return string(AppendDate(nil, t))
+}
+
+// AppendDate is like [FormatDate] but appends to b.
+func AppendDate(b []byte, t time.Time) []byte {
+	return appendExtended(b, t, DateFormat)
+}
+
+// ParseDate parses a date, formatted like [FormatDate], in UTC.
+func ParseDate(s string) (time.Time, error) {
+	return parseExtended(DateFormat, s)
+}
+
+// FormatTimestamp formats t using [TimestampFormat], or the equivalent
+// extended format, if the year is outside of 0000-9999. See
+// [ExtendedYearDigits]. Offsets that are not whole minutes are formatted
+// using [TimestampSecondsOffsetFormat], so they aren't truncated.
+func FormatTimestamp(t time.Time) string {
+	return string(AppendTimestamp(nil, t))
+}
+
+// AppendTimestamp is like [FormatTimestamp] but appends to b.
+func AppendTimestamp(b []byte, t time.Time) []byte {
+	if _, offset := t.Zone(); offset%60 != 0 {
+		// N.B. the time package mis-formats offsets between -1m and 0
+		b = appendExtended(b, t, TimestampSecondsOffsetFormat[:len(TimestampSecondsOffsetFormat)-len(`Z07:00:00`)])
+		return appendOffsetSeconds(b, offset)
+	}
+	return appendExtended(b, t, TimestampFormat)
+}
+
+// ParseTimestamp parses a timestamp, formatted like [FormatTimestamp].
+// Timestamps without an offset are interpreted as UTC.
+func ParseTimestamp(s string) (time.Time, error) {
+	t, err := parseExtended(TimestampFormat, s)
+	if err != nil {
+		if v, ok := parseSecondsOffsetTimestamp(s); ok {
+			return v, nil
+		}
+	}
+	return t, err
+}
+
+// parseSecondsOffsetTimestamp parses a timestamp formatted per
+// [TimestampSecondsOffsetFormat]. N.B. the time package mis-parses offsets
+// between -1m and 0.
+func parseSecondsOffsetTimestamp(s string) (time.Time, bool) {
+	const n = len(`+00:00:00`)
+	if len(s) < n {
+		return time.Time{}, false
+	}
+	b := s[len(s)-n:]
+	if (b[0] != '+' && b[0] != '-') || b[3] != ':' || b[6] != ':' {
+		return time.Time{}, false
+	}
+	var offset int
+	for i, limit := range [...]int{24, 60, 60} {
+		hi, lo := b[1+i*3], b[2+i*3]
+		if hi < '0' || hi > '9' || lo < '0' || lo > '9' {
+			return time.Time{}, false
+		}
+		v := int(hi-'0')*10 + int(lo-'0')
+		if v >= limit {
+			return time.Time{}, false
+		}
+		offset = offset*60 + v
+	}
+	if b[0] == '-' {
+		offset = -offset
+	}
+	t, err := parseExtended(TimestampSecondsOffsetFormat[:len(TimestampSecondsOffsetFormat)-len(`Z07:00:00`)], s[:len(s)-n])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.Add(-time.Duration(offset) * time.Second).In(time.FixedZone(``, offset)), true
+}
+
+// appendOffsetSeconds appends the offset, per [TimestampSecondsOffsetFormat],
+// e.g. "-00:00:30".
+func appendOffsetSeconds(b []byte, offset int) []byte {
+	if offset < 0 {
+		b = append(b, '-')
+		offset = -offset
+	} else {
+		b = append(b, '+')
+	}
+	for i, v := range [...]int{offset / 3600, offset / 60 % 60, offset % 60} {
+		if i != 0 {
+			b = append(b, ':')
+		}
+		b = append(b, byte('0'+v/10), byte('0'+v%10))
+	}
+	return b
+}
+
+// appendExtended formats t using layout, which must start with a 4-digit
+// year, substituting the extended year format where necessary.
+func appendExtended(b []byte, t time.Time, layout string) []byte {
+	year := t.Year()
+	if year >= 0 && year <= 9999 {
+		return t.AppendFormat(b, layout)
+	}
+
+	if year < 0 {
+		b = append(b, '-')
+	} else {
+		b = append(b, '+')
+	}
+	digits := strconv.FormatUint(absYear(year), 10)
+	for i := len(digits); i < ExtendedYearDigits; i++ {
+		b = append(b, '0')
+	}
+	b = append(b, digits...)
+
+	// format the remainder using a proxy year, which must have the same
+	// number of days in February
+	name, offset := t.Zone()
+	proxy := time.Date(proxyYear(year), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.FixedZone(name, offset))
+	return proxy.AppendFormat(b, layout[4:])
+}
+
+func parseExtended(layout, s string) (time.Time, error) {
+	if s == `` || (s[0] != '+' && s[0] != '-') {
+		return time.ParseInLocation(layout, s, time.UTC)
+	}
+
+	i := 1
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if n := i - 1; n < ExtendedYearDigits || n > maxExtendedYearDigits {
+		return time.Time{}, errors.New(`invalid extended year: ` + strconv.Quote(s))
+	}
+	year64, err := strconv.ParseInt(s[1:i], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s[0] == '-' {
+		year64 = -year64
+	}
+	year := int(year64)
+	if year64 < minExtendedYear || year64 > maxExtendedYear || int64(year) != year64 {
+		return time.Time{}, errors.New(`extended year out of range: ` + strconv.Quote(s))
+	}
+
+	proxy, err := time.ParseInLocation(layout, strconv.Itoa(proxyYear(year))+s[i:], time.UTC)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(year, proxy.Month(), proxy.Day(), proxy.Hour(), proxy.Minute(), proxy.Second(), proxy.Nanosecond(), proxy.Location()), nil
+}
+
+// proxyYear returns a year within 0000-9999, that is a leap year, iff year
+// is a leap year (proleptic Gregorian calendar).
+func proxyYear(year int) int {
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		return 2000
+	}
+	return 2001
+}
+
+func absYear(year int) uint64 {
+	if year < 0 {
+		return uint64(-int64(year))
+	}
+	return uint64(year)
+}
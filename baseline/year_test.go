@@ -0,0 +1,174 @@
+package baseline
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFormatDate_extended(t *testing.T) {
+	for _, tc := range [...]struct {
+		year int
+		date string
+	}{
+		{0, "0000-03-01"},
+		{9999, "9999-03-01"},
+		{10000, "+10000-03-01"},
+		{-1, "-00001-03-01"},
+		{-10000, "-10000-03-01"},
+		{123456, "+123456-03-01"},
+	} {
+		d := time.Date(tc.year, 3, 1, 0, 0, 0, 0, time.UTC)
+		if s := FormatDate(d); s != tc.date {
+			t.Errorf("expected %s, got %s", tc.date, s)
+		}
+		if v, err := ParseDate(tc.date); err != nil || !v.Equal(d) || v.Location() != time.UTC {
+			t.Errorf("%s: %v %v", tc.date, v, err)
+		}
+		if err := ValidateDate(tc.date); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestValidateDate_extended(t *testing.T) {
+	for _, s := range [...]string{
+		"+02024-01-01", // non-canonical
+		"+1000-01-01",  // too few digits
+		"10000-01-01",  // missing sign
+		"+10001-02-29", // not a leap year
+		"+10000-13-01",
+		"-",
+		"+",
+	} {
+		if err := ValidateDate(s); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+	if err := ValidateDate("+10000-02-29"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseDate_limits(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip(`the limits exceed the range of int`)
+	}
+	for _, s := range [...]string{
+		"-292277022398-01-01",
+		"+292277026595-12-31",
+	} {
+		if err := ValidateDate(s); err != nil {
+			t.Errorf("%s: %v", s, err)
+		}
+	}
+	for _, s := range [...]string{
+		"-292277022399-12-31",
+		"+292277026596-01-01",
+		"+999999999999-01-01",
+		"-999999999999-01-01",
+	} {
+		if _, err := ParseDate(s); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+	for _, s := range [...]string{
+		"-292277022398-01-01T00:00:00+23:59",
+		"+292277026595-12-31T23:59:59.999999999-23:59",
+	} {
+		v, err := ParseTimestamp(s)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if u := time.Unix(v.Unix(), int64(v.Nanosecond())); !u.Equal(v) || FormatTimestamp(v) != s {
+			t.Errorf("%s: overflowed: %s", s, FormatTimestamp(v))
+		}
+	}
+}
+
+func TestExampleTimestampToDate_extended(t *testing.T) {
+	start, err := ParseTimestamp("+10000-01-01T00:00:00.5+10:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	end, err := ParseTimestamp("-00001-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	startDate, endDate := ExampleTimestampToDate(start, end)
+	if startDate != "+10000-01-01" || endDate != "-00002-12-31" {
+		t.Fatal(startDate, endDate)
+	}
+	start, end = ExampleDateToTimestamp(startDate, endDate)
+	if s := FormatTimestamp(start); s != "+10000-01-01T00:00:00Z" {
+		t.Error(s)
+	}
+	if s := FormatTimestamp(end); s != "-00001-01-01T00:00:00Z" {
+		t.Error(s)
+	}
+	if !MatchesDate("-00001-12-31", "+10000-01-01", "+10000-01-01") {
+		t.Error("expected match")
+	}
+	if MatchesDate("", "9999-12-31", "+10000-01-01") {
+		t.Error("expected no match")
+	}
+}
+
+func TestFormatTimestamp_secondsOffset(t *testing.T) {
+	for _, tc := range [...]struct {
+		offset int
+		s      string
+	}{
+		{1172, "1900-01-01T00:00:00+00:19:32"},
+		{-17762, "1900-01-01T00:00:00-04:56:02"},
+		{-30, "1900-01-01T00:00:00-00:00:30"},
+		{20700, "1900-01-01T00:00:00+05:45"},
+	} {
+		v := time.Date(1900, 1, 1, 0, 0, 0, 0, time.FixedZone("", tc.offset))
+		if s := FormatTimestamp(v); s != tc.s {
+			t.Errorf("expected %s, got %s", tc.s, s)
+		}
+		p, err := ParseTimestamp(tc.s)
+		if err != nil || !p.Equal(v) {
+			t.Errorf("%s: %v %v", tc.s, p, err)
+		} else if _, offset := p.Zone(); offset != tc.offset {
+			t.Errorf("%s: expected offset %d, got %d", tc.s, tc.offset, offset)
+		}
+	}
+	if s := FormatTimestamp(time.Date(-1, 1, 1, 0, 0, 0, 0, time.FixedZone("", 1172))); s != "-00001-01-01T00:00:00+00:19:32" {
+		t.Errorf("unexpected extended timestamp: %s", s)
+	}
+	if _, err := ParseTimestamp("1900-01-01T00:00:00+00:19:32:00"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func FuzzParseTimestamp(f *testing.F) {
+	f.Add(int64(0), int64(0), 0)
+	f.Add(int64(253402300800), int64(1), 36000)     // +10000-01-01
+	f.Add(int64(-62135596800-86400), int64(0), -60) // -0001-12-31
+	f.Add(int64(math.MaxInt64/2), int64(999999999), 0)
+	f.Add(int64(-2208988800), int64(0), 1172) // LMT (Europe/Amsterdam)
+	f.Fuzz(func(t *testing.T, sec, nsec int64, offset int) {
+		if strconv.IntSize < 64 && (sec >= 1<<55 || sec < -1<<55) {
+			t.Skip(`the year exceeds the range of int`)
+		}
+		offset = offset % (24 * 60 * 60)
+		v := time.Unix(sec, nsec).In(time.FixedZone("", offset))
+		s := FormatTimestamp(v)
+		p, err := ParseTimestamp(s)
+		if err != nil {
+			t.Fatal(s, err)
+		}
+		if !p.Equal(v) {
+			t.Fatalf("%s: expected %s, got %s", s, v, p)
+		}
+		if _, o := p.Zone(); o != offset {
+			t.Fatalf("%s: expected offset %d, got %d", s, offset, o)
+		}
+		if d := FormatDate(v.UTC()); ValidateDate(d) != nil {
+			t.Fatal(d)
+		}
+	})
+}
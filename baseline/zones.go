@@ -0,0 +1,141 @@
+package baseline
+
+import (
+	"archive/zip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// zoneTransitionsUntil bounds [ZoneTransitions], for zones which have
+// recurring (e.g. daylight saving) transitions.
+var zoneTransitionsUntil = time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ZoneNames returns the sorted names of the zones available in the system
+// tzdata, i.e. the first of the sources searched by [time.LoadLocation],
+// excluding the embedded copy, if any. The names may be loaded using
+// [time.LoadLocation]. It returns nil if no tzdata is found.
+func ZoneNames() []string {
+	var sources []string
+	if v := os.Getenv(`ZONEINFO`); v != `` {
+		sources = append(sources, v)
+	}
+	sources = append(sources,
+		`/usr/share/zoneinfo/`,
+		`/usr/share/lib/zoneinfo/`,
+		`/usr/lib/locale/TZ/`,
+		`/etc/zoneinfo/`,
+		filepath.Join(runtime.GOROOT(), `lib`, `time`, `zoneinfo.zip`),
+	)
+	for _, source := range sources {
+		if names := zoneNames(source); len(names) != 0 {
+			return names
+		}
+	}
+	return nil
+}
+
+func zoneNames(source string) (names []string) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil
+	}
+	var fsys fs.FS
+	if info.IsDir() {
+		fsys = os.DirFS(source)
+	} else {
+		r, err := zip.OpenReader(source)
+		if err != nil {
+			return nil
+		}
+		defer r.Close()
+		fsys = r
+	}
+	_ = fs.WalkDir(fsys, `.`, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			// posix and right are copies, the latter with leap seconds
+			if name == `posix` || name == `right` {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		// zone names start with an uppercase letter, unlike zone.tab etc
+		if name[0] < 'A' || name[0] > 'Z' || name == `Factory` || strings.HasSuffix(name, `.tab`) {
+			return nil
+		}
+		if b, err := fs.ReadFile(fsys, name); err != nil || !strings.HasPrefix(string(b), `TZif`) {
+			return nil
+		}
+		names = append(names, name)
+		return nil
+	})
+	slices.Sort(names)
+	return names
+}
+
+// ZoneTransitions calls yield with the instants at which the offset of loc
+// changes, in order, until yield returns false, or the transitions reach
+// 2040, i.e. each is the start of a zone, per [time.Time.ZoneBounds].
+func ZoneTransitions(loc *time.Location, yield func(t time.Time) bool) {
+	t := time.Date(1800, 1, 1, 0, 0, 0, 0, loc)
+	for {
+		_, end := t.ZoneBounds()
+		if end == (time.Time{}) || !end.Before(zoneTransitionsUntil) {
+			return
+		}
+		// N.B. the zone may change without the offset changing
+		_, before := end.Add(-time.Nanosecond).Zone()
+		if _, after := end.Zone(); before != after && !yield(end) {
+			return
+		}
+		t = end
+	}
+}
+
+// addZoneTransitionSeeds adds seeds to [FuzzTimestampToDate], for the
+// transitions of each of [ZoneNames], at (and 1ns either side of) each
+// transition, in the zone's offset at that instant, as each bound of a two
+// day range. Transitions are deduplicated by the offsets either side, and the
+// time of day, to keep the number of seeds reasonable, while still covering
+// e.g. local mean time offsets, which have seconds.
+func addZoneTransitionSeeds(f *testing.F) {
+	type key struct {
+		before, after int
+		clock         time.Duration
+	}
+	seen := make(map[key]struct{})
+	for _, name := range ZoneNames() {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		ZoneTransitions(loc, func(t time.Time) bool {
+			_, before := t.Add(-time.Nanosecond).Zone()
+			_, after := t.Zone()
+			k := key{before, after, t.Sub(WidenStartTime(t))}
+			if _, ok := seen[k]; ok {
+				return true
+			}
+			seen[k] = struct{}{}
+			value := WidenStartTime(t).UnixNano()
+			for _, d := range [...]time.Duration{-time.Nanosecond, 0, time.Nanosecond} {
+				v := t.Add(d)
+				_, offset := v.Zone()
+				offset = encodeFuzzOffset(offset)
+				f.Add(v.UnixNano(), offset, v.Add(2*oneDay).UnixNano(), offset, value, false, false)
+				f.Add(v.Add(-2*oneDay).UnixNano(), offset, v.UnixNano(), offset, value, false, false)
+				f.Add(v.UnixNano(), offset, int64(0), 0, value, false, true)
+				f.Add(int64(0), 0, v.UnixNano(), offset, value, true, false)
+			}
+			return true
+		})
+	}
+}
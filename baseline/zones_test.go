@@ -0,0 +1,43 @@
+package baseline
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestZoneTransitions(t *testing.T) {
+	names := ZoneNames()
+	if !slices.Contains(names, `America/New_York`) {
+		t.Skip(`tzdata not available`)
+	}
+	if slices.ContainsFunc(names, func(name string) bool { return name == `zone.tab` || name == `posix/UTC` }) {
+		t.Error(`unexpected zone names`)
+	}
+	loc, err := time.LoadLocation(`America/New_York`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var transitions []string
+	ZoneTransitions(loc, func(v time.Time) bool {
+		if v.Year() == 2024 {
+			transitions = append(transitions, FormatTimestamp(v))
+		}
+		return v.Year() <= 2024
+	})
+	if !slices.Equal(transitions, []string{`2024-03-10T03:00:00-04:00`, `2024-11-03T01:00:00-05:00`}) {
+		t.Errorf("unexpected transitions: %q", transitions)
+	}
+
+	// the first transition is from local mean time, which has seconds
+	loc, err = time.LoadLocation(`Europe/Amsterdam`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ZoneTransitions(loc, func(v time.Time) bool {
+		if _, offset := v.Add(-time.Nanosecond).Zone(); offset != 1172 {
+			t.Errorf("unexpected offset: %d", offset)
+		}
+		return false
+	})
+}
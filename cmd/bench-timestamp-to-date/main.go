@@ -0,0 +1,128 @@
+// Run: go run cmd/bench-timestamp-to-date/main.go [-fixtures path] [-n calls] [-warmup calls] [-overhead=false] ./path/to/your/external/command arg1 arg2 arg3
+//
+// Measures the throughput, and latency percentiles, of converting timestamp
+// ranges using the external command, which is called sequentially, cycling
+// through the ranges of the fixtures (see [baseline.LoadFixtures]), or the
+// built-in examples. The -warmup calls are made first, and aren't measured.
+//
+// Unless -overhead=false, the round-trip overhead, i.e. of the protocol and
+// process communication, is measured separately, using an instance of this
+// command that echoes its input, and is reported on its own. The difference
+// of the mean durations estimates the mean time taken by the conversion
+// itself (percentiles can't be subtracted, so aren't estimated).
+//
+// The external command should read pairs of tab-separated timestamps from
+// stdin, and write pairs of tab-separated dates to stdout.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/extcmd"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/timestamptodate"
+	"io"
+	"os"
+	"time"
+)
+
+// echoEnv is set to run this command as an echo server, to measure the
+// round-trip overhead.
+const echoEnv = `BENCH_TIMESTAMP_TO_DATE_ECHO`
+
+func main() {
+	if os.Getenv(echoEnv) != `` {
+		if err := echo(os.Stdin, os.Stdout); err != nil {
+			_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+	fixturesFlag := flag.String(`fixtures`, ``, `path to a fixtures file (.json or .tsv)`)
+	nFlag := flag.Int(`n`, 10000, `number of calls to measure`)
+	warmupFlag := flag.Int(`warmup`, 100, `number of calls to make before measuring`)
+	overheadFlag := flag.Bool(`overhead`, true, `measure the round-trip overhead separately`)
+	flag.Parse()
+	if flag.NArg() == 0 || *nFlag < 1 || *warmupFlag < 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	ranges := baseline.TimestampRangeValues
+	if *fixturesFlag != `` {
+		fixtures, err := baseline.LoadFixtures(*fixturesFlag)
+		if err != nil {
+			_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
+			os.Exit(1)
+		}
+		ranges = fixtures.TimestampRangeValues
+	}
+	if err := run(context.Background(), os.Stdout, *nFlag, *warmupFlag, *overheadFlag, ranges, flag.Arg(0), flag.Args()[1:]...); err != nil {
+		_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, w io.Writer, n, warmup int, overhead bool, ranges [][2]string, command string, args ...string) error {
+	latencies, err := measure(ctx, n, warmup, ranges, command, args...)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "command: %s\n", latencies); err != nil || !overhead {
+		return err
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if err := os.Setenv(echoEnv, `1`); err != nil {
+		return err
+	}
+	defer os.Unsetenv(echoEnv)
+	overheadLatencies, err := measure(ctx, n, warmup, ranges, self)
+	if err != nil {
+		return fmt.Errorf(`overhead: %w`, err)
+	}
+
+	_, err = fmt.Fprintf(w, "overhead: %s\nestimated conversion: mean %s per call\n", overheadLatencies, max(latencies.Mean()-overheadLatencies.Mean(), 0))
+	return err
+}
+
+func measure(ctx context.Context, n, warmup int, ranges [][2]string, command string, args ...string) (latencies *baseline.Latencies, err error) {
+	err = extcmd.Run[[2]time.Time, [2]string](
+		ctx,
+		nil,
+		command,
+		args,
+		"",
+		timestamptodate.AppendInput,
+		bufio.ScanLines,
+		timestamptodate.ParseOutput,
+		func(ctx context.Context, call func(input [2]time.Time) ([2]string, error)) error {
+			convert := timestamptodate.CallToConvert(call)
+			if warmup != 0 {
+				if _, err := baseline.MeasureTimestampToDate(ctx, warmup, ranges, convert); err != nil {
+					return err
+				}
+			}
+			var err error
+			latencies, err = baseline.MeasureTimestampToDate(ctx, n, ranges, convert)
+			return err
+		},
+	)
+	return
+}
+
+// echo writes each line of r to w, as the output of the protocol, i.e. a
+// pair of tab-separated values, unmodified.
+func echo(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if _, err := w.Write(append(scanner.Bytes(), '\n')); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
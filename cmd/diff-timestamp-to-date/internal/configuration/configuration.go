@@ -0,0 +1,70 @@
+package configuration
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+const Variable = `github.com/joeycumines/dates-timestamps-and-aggregated-data/cmd/diff-timestamp-to-date/internal/configuration.optionsBase64`
+
+type (
+	Options struct {
+		// Commands are the implementations to compare, of which there must
+		// be at least two.
+		Commands []Command `json:"commands"`
+		// Reference enables the Go baseline, as a tie-breaker.
+		Reference bool `json:"reference"`
+	}
+
+	Command struct {
+		Cmd  string   `json:"cmd"`
+		Args []string `json:"args"`
+		Dir  string   `json:"dir"`
+	}
+)
+
+var optionsBase64 string
+
+func Skip() bool {
+	return optionsBase64 == ``
+}
+
+func Encode(options Options) (string, error) {
+	if err := options.validate(); err != nil {
+		return ``, err
+	}
+	b, err := json.Marshal(options)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func Decode() (options Options, err error) {
+	if optionsBase64 == "" {
+		err = errors.New("optionsBase64 is empty")
+		return
+	}
+	b, err := base64.StdEncoding.DecodeString(optionsBase64)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(b, &options); err != nil {
+		return
+	}
+	err = options.validate()
+	return
+}
+
+func (x Options) validate() error {
+	if len(x.Commands) < 2 {
+		return errors.New("options.Commands must have at least two commands")
+	}
+	for _, c := range x.Commands {
+		if c.Cmd == "" {
+			return errors.New("options.Commands has an empty Cmd")
+		}
+	}
+	return nil
+}
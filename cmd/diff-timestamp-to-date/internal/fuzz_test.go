@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/cmd/diff-timestamp-to-date/internal/configuration"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/extcmd"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/timestamptodate"
+	"testing"
+	"time"
+)
+
+func FuzzTimestampToDateDifferential(f *testing.F) {
+	if configuration.Skip() {
+		f.SkipNow()
+	}
+
+	options, err := configuration.Decode()
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	var reference baseline.TimestampToDate
+	if options.Reference {
+		reference = baseline.ExampleTimestampToDate
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f.Cleanup(cancel)
+
+	// starts each command, in turn, nested, then runs the fuzz test
+	var start func(ctx context.Context, impls []baseline.NamedTimestampToDate) error
+	start = func(ctx context.Context, impls []baseline.NamedTimestampToDate) error {
+		if len(impls) == len(options.Commands) {
+			// N.B. the value is unused, so a single value avoids redundant seeds
+			baseline.FuzzTimestampToDateDifferential(f, baseline.TimestampRangeValues, baseline.DateValues[:1], reference, impls...)
+			return nil
+		}
+		c := options.Commands[len(impls)]
+		return extcmd.Run[[2]time.Time, [2]string](
+			ctx,
+			f.Helper,
+			c.Cmd,
+			c.Args,
+			c.Dir,
+			timestamptodate.AppendInput,
+			bufio.ScanLines,
+			timestamptodate.ParseOutput,
+			func(ctx context.Context, call func(input [2]time.Time) ([2]string, error)) error {
+				f.Helper()
+				return start(ctx, append(impls, baseline.NamedTimestampToDate{
+					Name:    string(rune('a' + len(impls))),
+					Convert: timestamptodate.CallToConvert(call),
+				}))
+			},
+		)
+	}
+	if err := start(ctx, nil); err != nil {
+		f.Fatal(err)
+	}
+}
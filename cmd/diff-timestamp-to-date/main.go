@@ -0,0 +1,196 @@
+// Run: go run cmd/diff-timestamp-to-date/main.go [-fixtures path] [-random n] [-seed n] [-reference] [-fuzz] ./path/to/command/a arg1 -- ./path/to/command/b arg1
+//
+// The commands, separated by "--", must implement the
+// verify-timestamp-to-date protocol, and are named a, b, etc, in order.
+// Each is fed the same timestamp ranges, and every range for which they
+// produce different dates is reported, and the exit code is non-zero. The
+// ranges are those of the fixtures (the built-in examples, by default, see
+// -fixtures), with either bound unset, plus -random pseudo-random ranges.
+// The -reference flag includes the result of the Go baseline, for each
+// divergence, to break ties.
+//
+// With -fuzz, the commands are instead fuzz tested against each other, using
+// go test, similar to cmd/fuzz-timestamp-to-date.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/cmd/diff-timestamp-to-date/internal/configuration"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/extcmd"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/quoted"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/timestamptodate"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+func main() {
+	fixturesFlag := flag.String(`fixtures`, ``, `path to a fixtures file (.json or .tsv)`)
+	randomFlag := flag.Int(`random`, 1000, `number of pseudo-random ranges`)
+	seedFlag := flag.Uint64(`seed`, 1, `seed for the pseudo-random ranges`)
+	referenceFlag := flag.Bool(`reference`, false, `include the Go baseline, to break ties`)
+	fuzzFlag := flag.Bool(`fuzz`, false, `fuzz test the commands against each other`)
+	flag.Parse()
+
+	var commands [][]string
+	for args := flag.Args(); len(args) != 0; {
+		i := slices.Index(args, `--`)
+		if i == -1 {
+			i = len(args)
+		}
+		commands = append(commands, args[:i])
+		args = args[min(i+1, len(args)):]
+	}
+	if len(commands) < 2 || slices.ContainsFunc(commands, func(v []string) bool { return len(v) == 0 }) || *randomFlag < 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	if *fuzzFlag {
+		err = runFuzz(context.Background(), commands, *referenceFlag)
+	} else {
+		fixtures := baseline.ExampleFixtures()
+		if *fixturesFlag != `` {
+			fixtures, err = baseline.LoadFixtures(*fixturesFlag)
+		}
+		var ranges [][2]time.Time
+		if err == nil {
+			ranges, err = baseline.TimestampRangeVariants(fixtures.TimestampRangeValues)
+		}
+		if err == nil {
+			ranges = append(ranges, baseline.RandomTimestampRanges(*seedFlag, *randomFlag)...)
+			var ok bool
+			ok, err = run(context.Background(), os.Stdout, commands, ranges, *referenceFlag)
+			if err == nil && !ok {
+				os.Exit(1)
+			}
+		}
+	}
+	if err != nil {
+		_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
+		os.Exit(1)
+	}
+}
+
+// run compares the commands, writing each divergence to w, returning false
+// if there were any.
+func run(ctx context.Context, w io.Writer, commands [][]string, ranges [][2]time.Time, reference bool) (ok bool, err error) {
+	var referenceConvert baseline.TimestampToDate
+	if reference {
+		referenceConvert = baseline.ExampleTimestampToDate
+	}
+	for i, c := range commands {
+		_, _ = fmt.Fprintf(w, "%c: %s\n", 'a'+i, strings.Join(c, ` `))
+	}
+
+	// starts each command, in turn, nested, then runs the comparison
+	var start func(ctx context.Context, impls []baseline.NamedTimestampToDate) error
+	start = func(ctx context.Context, impls []baseline.NamedTimestampToDate) error {
+		if len(impls) == len(commands) {
+			divergences, compared, err := baseline.DiffTimestampToDateRanges(ctx, ranges, referenceConvert, impls...)
+			for _, v := range divergences {
+				_, _ = fmt.Fprintf(w, "DIVERGENCE: %s\n", v)
+			}
+			_, _ = fmt.Fprintf(w, "summary: %d divergences, of %d ranges\n", len(divergences), compared)
+			ok = len(divergences) == 0
+			return err
+		}
+		c := commands[len(impls)]
+		return extcmd.Run[[2]time.Time, [2]string](
+			ctx,
+			nil,
+			c[0],
+			c[1:],
+			"",
+			timestamptodate.AppendInput,
+			bufio.ScanLines,
+			timestamptodate.ParseOutput,
+			func(ctx context.Context, call func(input [2]time.Time) ([2]string, error)) error {
+				return start(ctx, append(impls, baseline.NamedTimestampToDate{
+					Name:    string(rune('a' + len(impls))),
+					Convert: timestamptodate.CallToConvert(call),
+				}))
+			},
+		)
+	}
+	err = start(ctx, nil)
+	return
+}
+
+func runFuzz(ctx context.Context, commands [][]string, reference bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	_, source, _, ok := runtime.Caller(0)
+	if !ok {
+		panic("failed to find caller source")
+	}
+
+	var ldflags string
+	{
+		var vals []string
+
+		options := configuration.Options{Reference: reference}
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		for _, c := range commands {
+			options.Commands = append(options.Commands, configuration.Command{
+				Cmd:  c[0],
+				Args: c[1:],
+				Dir:  dir,
+			})
+		}
+		if v, err := configuration.Encode(options); err != nil {
+			return err
+		} else {
+			vals = append(vals, `-X`, configuration.Variable+`=`+v)
+		}
+
+		ldflags, err = quoted.Join(vals)
+		if err != nil {
+			return err
+		}
+	}
+
+	c := exec.CommandContext(
+		ctx,
+		`go`, `test`,
+		`-ldflags=`+ldflags,
+		`-fuzz=FuzzTimestampToDateDifferential`,
+	)
+	c.Dir = filepath.Join(filepath.Dir(source), `internal`)
+
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Stdin = os.Stdin
+
+	ch := make(chan os.Signal, 8)
+	signal.Notify(ch)
+	defer close(ch)
+	defer signal.Stop(ch)
+
+	if err := c.Start(); err != nil {
+		return err
+	}
+
+	go func() {
+		for sig := range ch {
+			_ = c.Process.Signal(sig)
+		}
+	}()
+
+	return c.Wait()
+}
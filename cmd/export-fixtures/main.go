@@ -0,0 +1,58 @@
+// Run: go run cmd/export-fixtures/main.go [-format json|tsv] [-o path]
+//
+// Exports the built-in example values and matches, in one of the on-disk
+// fixture formats, see [baseline.LoadFixtures]. The format defaults to the
+// extension of the output file, or json, if writing to stdout.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		formatFlag = flag.String(`format`, ``, `output format, either "json" or "tsv"`)
+		outputFlag = flag.String(`o`, ``, `output file, defaults to stdout`)
+	)
+	flag.Parse()
+	if err := run(*formatFlag, *outputFlag); err != nil {
+		_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error() + "\n")
+		os.Exit(1)
+	}
+}
+
+func run(outputFormat, output string) error {
+	if outputFormat == `` {
+		outputFormat = strings.TrimPrefix(filepath.Ext(output), `.`)
+		if outputFormat == `` {
+			outputFormat = `json`
+		}
+	}
+
+	fixtures := baseline.ExampleFixtures()
+	var buf bytes.Buffer
+	var err error
+	switch outputFormat {
+	case `json`:
+		err = fixtures.WriteJSON(&buf)
+	case `tsv`:
+		err = fixtures.WriteTSV(&buf)
+	default:
+		err = fmt.Errorf(`unknown format: %q`, outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	if output == `` {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(output, buf.Bytes(), 0644)
+}
@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/cmd/fuzz-date-to-timestamp/internal/configuration"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/datetotimestamp"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/extcmd"
+	"testing"
+	"time"
+)
+
+func FuzzDateToTimestamp(f *testing.F) {
+	if configuration.Skip() {
+		f.SkipNow()
+	}
+
+	options, err := configuration.Decode()
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f.Cleanup(cancel)
+	if err := extcmd.Run[[2]string, [2]time.Time](
+		ctx,
+		f.Helper,
+		options.Cmd,
+		options.Args,
+		options.Dir,
+		datetotimestamp.AppendInput,
+		bufio.ScanLines,
+		datetotimestamp.ParseOutput,
+		func(ctx context.Context, call func(input [2]string) ([2]time.Time, error)) error {
+			f.Helper()
+			baseline.FuzzDateToTimestamp(f, baseline.DateRangeValues, baseline.TimestampValues, datetotimestamp.CallToConvert(call))
+			return nil
+		},
+	); err != nil {
+		f.Fatal(err)
+	}
+}
@@ -0,0 +1,113 @@
+// Run: go run cmd/generate-matches/main.go [-format go|json] [-package name] [-o path]
+//
+// Generates the expected matches, for the example values, using the oracle
+// functions, i.e. [baseline.ExampleMatches], as formatted Go source, or as
+// JSON, for use by implementations in other languages. The JSON is an object,
+// with a list of [range_i, range_j, value_k] triplets, per direction.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"go/format"
+	"io"
+	"os"
+)
+
+func main() {
+	var (
+		formatFlag  = flag.String(`format`, `go`, `output format, either "go" or "json"`)
+		packageFlag = flag.String(`package`, `baseline`, `package name, for the "go" format`)
+		outputFlag  = flag.String(`o`, ``, `output file, defaults to stdout`)
+	)
+	flag.Parse()
+	if err := run(*formatFlag, *packageFlag, *outputFlag); err != nil {
+		_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error() + "\n")
+		os.Exit(1)
+	}
+}
+
+func run(outputFormat, packageName, output string) error {
+	timestampToDate, dateToTimestamp, err := baseline.GenerateExampleMatches()
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	switch outputFormat {
+	case `go`:
+		b, err = formatGo(packageName, timestampToDate, dateToTimestamp)
+	case `json`:
+		b, err = formatJSON(timestampToDate, dateToTimestamp)
+	default:
+		err = fmt.Errorf(`unknown format: %q`, outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	if output == `` {
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(output, b, 0644)
+}
+
+func formatGo(packageName string, timestampToDate, dateToTimestamp [][3]string) ([]byte, error) {
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "// Code generated by generate-matches; DO NOT EDIT.\n\npackage %s\n\n", packageName)
+	buf.WriteString("// ExampleMatches are all tuples like (range_i, range_j, value_k), which return\n")
+	buf.WriteString("// true, from their corresponding/appropriate comparison function, where\n")
+	buf.WriteString("// value_k differs in format (same-format is uninteresting).\n")
+	buf.WriteString("var ExampleMatches = map[[3]string]struct{}{\n")
+	writeSection(&buf, `TimestampToDate`, timestampToDate)
+	buf.WriteString("\n")
+	writeSection(&buf, `DateToTimestamp`, dateToTimestamp)
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+// formatJSON writes one triplet per line, to keep diffs readable.
+func formatJSON(timestampToDate, dateToTimestamp [][3]string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	for i, section := range [...]struct {
+		name    string
+		matches [][3]string
+	}{
+		{`timestampToDate`, timestampToDate},
+		{`dateToTimestamp`, dateToTimestamp},
+	} {
+		if i != 0 {
+			buf.WriteString(",\n")
+		}
+		_, _ = fmt.Fprintf(&buf, "\t%q: [", section.name)
+		for j, m := range section.matches {
+			if j != 0 {
+				buf.WriteByte(',')
+			}
+			b, err := json.Marshal(m)
+			if err != nil {
+				return nil, err
+			}
+			buf.WriteString("\n\t\t")
+			buf.Write(b)
+		}
+		buf.WriteString("\n\t]")
+	}
+	buf.WriteString("\n}\n")
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf(`invalid json output`)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeSection(w io.Writer, name string, matches [][3]string) {
+	_, _ = fmt.Fprintf(w, "// for %s\n\n", name)
+	for _, m := range matches {
+		_, _ = fmt.Fprintf(w, "{%q, %q, %q}: {},\n", m[0], m[1], m[2])
+	}
+}
@@ -0,0 +1,134 @@
+// Run: go run cmd/replay-timestamp-to-date/main.go [-corpus path]... [-fixtures path] [-export path] [-format text|jsonl|junit] [-parallel n] [-window n] [-ids] [-restarts n] [-retries n] [-timeout d] [-stderr] [-grace-period d] [-check-exit] [-check-unread-output] [./path/to/your/external/command arg1 arg2 arg3]
+//
+// Replays fuzz corpus entries (see cmd/fuzz-timestamp-to-date), and/or the
+// cases of a fixtures file (see [baseline.LoadFixtures]), against the
+// external command, using the same checks as [baseline.FuzzTimestampToDate],
+// without requiring the Go toolchain. Each -corpus may be a directory, e.g.
+// testdata/fuzz/FuzzTimestampToDate, or a single corpus file. Entries that
+// the fuzz test would skip are skipped.
+//
+// The -export flag writes the cases, with the expected results, as TSV (see
+// [baseline.WriteTimestampToDateCasesTSV]), to the given path, or stdout, if
+// "-", e.g. for use by the test suites of other implementations. The command
+// is optional, if -export is used. The remaining flags are common to the
+// verify commands, see [verifycmd].
+//
+// The external command should read pairs of tab-separated timestamps from
+// stdin, and write pairs of tab-separated dates to stdout.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/extcmd"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/fuzzcorpus"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/timestamptodate"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/verifycmd"
+	"os"
+	"time"
+)
+
+func main() {
+	var corpora []string
+	flag.Func(`corpus`, `path to a fuzz corpus directory or file (repeatable)`, func(s string) error {
+		corpora = append(corpora, s)
+		return nil
+	})
+	fixturesFlag := flag.String(`fixtures`, ``, `path to a fixtures file (.json or .tsv)`)
+	exportFlag := flag.String(`export`, ``, `path to write the cases as TSV, or - for stdout`)
+	flags := verifycmd.RegisterFlags()
+	flag.Parse()
+	if (len(corpora) == 0 && *fixturesFlag == ``) ||
+		(flag.NArg() == 0 && *exportFlag == ``) ||
+		!flags.Valid() {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	names, cases, err := load(corpora, *fixturesFlag)
+	if err == nil && *exportFlag != `` {
+		err = export(*exportFlag, names, cases)
+	}
+	if err != nil {
+		_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
+		os.Exit(1)
+	}
+	if flag.NArg() != 0 {
+		config := verifycmd.NewConfig[[2]time.Time, [2]string](flags, flag.Arg(0), flag.Args()[1:])
+		config.AppendInput, config.SplitOutput, config.ParseOutput = timestamptodate.AppendInput, bufio.ScanLines, timestamptodate.ParseOutput
+		if flags.IDs {
+			config.AppendInputID, config.ParseOutputID = timestamptodate.AppendInputID, timestamptodate.ParseOutputID
+		}
+		flags.Exit(run(context.Background(), config, flags.Concurrency(), names, cases))
+	}
+}
+
+// load reads the cases from each corpus, followed by the fixtures, if any.
+func load(corpora []string, fixtures string) (names []string, cases []baseline.TimestampToDateCase, err error) {
+	var skipped int
+	for _, corpus := range corpora {
+		entries, err := fuzzcorpus.ReadDir(corpus)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range entries {
+			c, err := baseline.DecodeTimestampToDateFuzzArgs(entry.Values...)
+			if errors.Is(err, baseline.ErrFuzzInputSkipped) {
+				skipped++
+				continue
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf(`%s: %s: %w`, corpus, entry.Name, err)
+			}
+			names = append(names, entry.Name)
+			cases = append(cases, c)
+		}
+	}
+	if fixtures != `` {
+		x, err := baseline.LoadFixtures(fixtures)
+		if err != nil {
+			return nil, nil, err
+		}
+		fixtureNames, fixtureCases := x.TimestampToDateCases()
+		names = append(names, fixtureNames...)
+		cases = append(cases, fixtureCases...)
+	}
+	if skipped != 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "skipped %d corpus entries\n", skipped)
+	}
+	return
+}
+
+func export(name string, names []string, cases []baseline.TimestampToDateCase) error {
+	if name == `-` {
+		return baseline.WriteTimestampToDateCasesTSV(os.Stdout, names, cases)
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := baseline.WriteTimestampToDateCasesTSV(f, names, cases); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func run(ctx context.Context, config extcmd.Config[[2]time.Time, [2]string], concurrency int, names []string, cases []baseline.TimestampToDateCase) (reports []*baseline.Report, err error) {
+	var calls verifycmd.Calls[[2]time.Time, [2]string]
+	err = config.Run(ctx, func(ctx context.Context, client *extcmd.Client[[2]time.Time, [2]string]) error {
+		reports = append(reports, baseline.ReplayTimestampToDatePerCase(ctx, concurrency, names, cases, func(i int) baseline.TimestampToDate {
+			return timestamptodate.CallToConvert(calls.Call(client, i))
+		}))
+		return nil
+	})
+	// N.B. after the command has exited, so all stderr has been read
+	for _, report := range reports {
+		calls.Attach(report)
+	}
+	return
+}
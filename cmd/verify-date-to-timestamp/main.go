@@ -0,0 +1,68 @@
+// Run: go run cmd/verify-date-to-timestamp/main.go [-fixtures path] [-format text|jsonl|junit] [-parallel n] [-window n] [-ids] [-restarts n] [-retries n] [-timeout d] [-stderr] [-grace-period d] [-check-exit] [-check-unread-output] ./path/to/your/external/command arg1 arg2 arg3
+//
+// The -fixtures flag may be used to load the ranges, values and expected
+// matches from a file, see [baseline.LoadFixtures], instead of using the
+// built-in examples. The remaining flags are common to the verify commands,
+// see [verifycmd].
+//
+// The external command should read pairs of tab-separated dates from stdin,
+// and write pairs of tab-separated timestamps to stdout.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/datetotimestamp"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/extcmd"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/verifycmd"
+	"os"
+	"time"
+)
+
+func main() {
+	fixturesFlag := flag.String(`fixtures`, ``, `path to a fixtures file (.json or .tsv)`)
+	flags := verifycmd.RegisterFlags()
+	flag.Parse()
+	if flag.NArg() == 0 || !flags.Valid() {
+		flag.Usage()
+		os.Exit(2)
+	}
+	fixtures := baseline.ExampleFixtures()
+	if *fixturesFlag != `` {
+		var err error
+		if fixtures, err = baseline.LoadFixtures(*fixturesFlag); err != nil {
+			_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
+			os.Exit(1)
+		}
+	}
+	config := verifycmd.NewConfig[[2]string, [2]time.Time](flags, flag.Arg(0), flag.Args()[1:])
+	config.AppendInput, config.SplitOutput, config.ParseOutput = datetotimestamp.AppendInput, bufio.ScanLines, datetotimestamp.ParseOutput
+	if flags.IDs {
+		config.AppendInputID, config.ParseOutputID = datetotimestamp.AppendInputID, datetotimestamp.ParseOutputID
+	}
+	flags.Exit(run(context.Background(), config, flags.Concurrency(), fixtures))
+}
+
+func run(ctx context.Context, config extcmd.Config[[2]string, [2]time.Time], concurrency int, fixtures *baseline.Fixtures) (reports []*baseline.Report, err error) {
+	var calls verifycmd.Calls[[2]string, [2]time.Time]
+	err = config.Run(ctx, func(ctx context.Context, client *extcmd.Client[[2]string, [2]time.Time]) error {
+		reports = append(reports, baseline.VerifyDateToTimestampPerCase(
+			ctx,
+			concurrency,
+			fixtures.DateRangeValues,
+			fixtures.TimestampValues,
+			fixtures.Matches(),
+			func(i int) baseline.DateToTimestamp {
+				return datetotimestamp.CallToConvert(calls.Call(client, i))
+			},
+		))
+		return nil
+	})
+	// N.B. after the command has exited, so all stderr has been read
+	for _, report := range reports {
+		calls.Attach(report)
+	}
+	return
+}
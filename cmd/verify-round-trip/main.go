@@ -0,0 +1,64 @@
+// Run: go run cmd/verify-round-trip/main.go ./path/to/timestamp-to-date/command arg1 -- ./path/to/date-to-timestamp/command arg1
+//
+// The commands before and after the "--" separator must implement the
+// verify-timestamp-to-date and verify-date-to-timestamp protocols,
+// respectively, and are tested for agreement with each other.
+package main
+
+import (
+	"bufio"
+	"context"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/datetotimestamp"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/extcmd"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/timestamptodate"
+	"os"
+	"slices"
+	"time"
+)
+
+func main() {
+	i := slices.Index(os.Args, `--`)
+	if i < 2 || i == len(os.Args)-1 {
+		_, _ = os.Stderr.WriteString("usage: verify-round-trip timestamp-to-date-command [args...] -- date-to-timestamp-command [args...]\n")
+		os.Exit(2)
+	}
+	if err := run(context.Background(), os.Args[1:i], os.Args[i+1:]); err != nil {
+		_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, timestampToDate, dateToTimestamp []string) error {
+	return extcmd.Run[[2]time.Time, [2]string](
+		ctx,
+		nil,
+		timestampToDate[0],
+		timestampToDate[1:],
+		"",
+		timestamptodate.AppendInput,
+		bufio.ScanLines,
+		timestamptodate.ParseOutput,
+		func(ctx context.Context, callTimestampToDate func(input [2]time.Time) ([2]string, error)) error {
+			return extcmd.Run[[2]string, [2]time.Time](
+				ctx,
+				nil,
+				dateToTimestamp[0],
+				dateToTimestamp[1:],
+				"",
+				datetotimestamp.AppendInput,
+				bufio.ScanLines,
+				datetotimestamp.ParseOutput,
+				func(ctx context.Context, callDateToTimestamp func(input [2]string) ([2]time.Time, error)) error {
+					return baseline.TestRoundTripExternal(
+						ctx,
+						baseline.TimestampRangeValues,
+						baseline.DateRangeValues,
+						timestamptodate.CallToConvert(callTimestampToDate),
+						datetotimestamp.CallToConvert(callDateToTimestamp),
+					)
+				},
+			)
+		},
+	)
+}
@@ -1,4 +1,11 @@
-// Run: go run cmd/verify-timestamp-to-date/main.go ./path/to/your/external/command arg1 arg2 arg3
+// Run: go run cmd/verify-timestamp-to-date/main.go [-fixtures path] [-format text|jsonl|junit] [-parallel n] [-window n] [-ids] [-restarts n] [-retries n] [-timeout d] [-stderr] [-grace-period d] [-check-exit] [-check-unread-output] [-contiguity] ./path/to/your/external/command arg1 arg2 arg3
+//
+// The -fixtures flag may be used to load the ranges, values and expected
+// matches from a file, see [baseline.LoadFixtures], instead of using the
+// built-in examples. The -contiguity flag adds a second report, checking
+// that adjacent ranges convert to adjacent dates, see
+// [baseline.VerifyContiguity]. The remaining flags are common to the verify
+// commands, see [verifycmd].
 //
 // The external command should read pairs of tab-separated timestamps from
 // stdin, and write pairs of tab-separated dates to stdout.
@@ -7,38 +14,76 @@ package main
 import (
 	"bufio"
 	"context"
+	"flag"
 	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
 	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/extcmd"
 	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/timestamptodate"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/verifycmd"
 	"os"
 	"time"
 )
 
 func main() {
-	if err := run(context.Background(), os.Args[1], os.Args[2:]...); err != nil {
-		_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
-		os.Exit(1)
+	fixturesFlag := flag.String(`fixtures`, ``, `path to a fixtures file (.json or .tsv)`)
+	flags := verifycmd.RegisterFlags()
+	contiguityFlag := flag.Bool(`contiguity`, false, `also check that adjacent ranges convert to adjacent dates`)
+	flag.Parse()
+	if flag.NArg() == 0 || !flags.Valid() {
+		flag.Usage()
+		os.Exit(2)
+	}
+	fixtures := baseline.ExampleFixtures()
+	if *fixturesFlag != `` {
+		var err error
+		if fixtures, err = baseline.LoadFixtures(*fixturesFlag); err != nil {
+			_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
+			os.Exit(1)
+		}
 	}
+	config := verifycmd.NewConfig[[2]time.Time, [2]string](flags, flag.Arg(0), flag.Args()[1:])
+	config.AppendInput, config.SplitOutput, config.ParseOutput = timestamptodate.AppendInput, bufio.ScanLines, timestamptodate.ParseOutput
+	if flags.IDs {
+		config.AppendInputID, config.ParseOutputID = timestamptodate.AppendInputID, timestamptodate.ParseOutputID
+	}
+	flags.Exit(run(context.Background(), config, flags.Concurrency(), fixtures, *contiguityFlag))
 }
 
-func run(ctx context.Context, command string, args ...string) error {
-	return extcmd.Run[[2]time.Time, [2]string](
-		ctx,
-		nil,
-		command,
-		args,
-		"",
-		timestamptodate.AppendInput,
-		bufio.ScanLines,
-		timestamptodate.ParseOutput,
-		func(ctx context.Context, call func(input [2]time.Time) ([2]string, error)) error {
-			return baseline.TestTimestampToDateExternal(
+func run(ctx context.Context, config extcmd.Config[[2]time.Time, [2]string], concurrency int, fixtures *baseline.Fixtures, contiguity bool) (reports []*baseline.Report, err error) {
+	var (
+		calls, contiguityCalls   verifycmd.Calls[[2]time.Time, [2]string]
+		report, contiguityReport *baseline.Report
+	)
+	err = config.Run(ctx, func(ctx context.Context, client *extcmd.Client[[2]time.Time, [2]string]) error {
+		report = baseline.VerifyTimestampToDatePerCase(
+			ctx,
+			concurrency,
+			fixtures.TimestampRangeValues,
+			fixtures.DateValues,
+			fixtures.Matches(),
+			func(i int) baseline.TimestampToDate {
+				return timestamptodate.CallToConvert(calls.Call(client, i))
+			},
+		)
+		if contiguity {
+			contiguityReport = baseline.VerifyContiguityPerCase(
 				ctx,
-				baseline.TimestampRangeValues,
-				baseline.DateValues,
-				baseline.ExampleMatches,
-				timestamptodate.CallToConvert(call),
+				concurrency,
+				baseline.ContiguityCases(1, 100),
+				func(i int) baseline.TimestampToDate {
+					return timestamptodate.CallToConvert(contiguityCalls.Call(client, i))
+				},
 			)
-		},
-	)
+		}
+		return nil
+	})
+	// N.B. after the command has exited, so all stderr has been read
+	if report != nil {
+		calls.Attach(report)
+		reports = append(reports, report)
+	}
+	if contiguityReport != nil {
+		contiguityCalls.Attach(contiguityReport)
+		reports = append(reports, contiguityReport)
+	}
+	return
 }
@@ -0,0 +1,43 @@
+// Run: go run cmd/verify-widen/main.go ./path/to/your/external/command arg1 arg2 arg3
+//
+// The external command should read pairs of tab-separated timestamps from
+// stdin, and write pairs of tab-separated (widened) timestamps to stdout.
+package main
+
+import (
+	"bufio"
+	"context"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/extcmd"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/widen"
+	"os"
+	"time"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1], os.Args[2:]...); err != nil {
+		_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, command string, args ...string) error {
+	return extcmd.Run[[2]time.Time, [2]time.Time](
+		ctx,
+		nil,
+		command,
+		args,
+		"",
+		widen.AppendInput,
+		bufio.ScanLines,
+		widen.ParseOutput,
+		func(ctx context.Context, call func(input [2]time.Time) ([2]time.Time, error)) error {
+			return baseline.TestWidenExternal(
+				ctx,
+				baseline.TimestampRangeValues,
+				baseline.TimestampValues,
+				widen.CallToWiden(call),
+			)
+		},
+	)
+}
@@ -0,0 +1,67 @@
+package datetotimestamp
+
+import (
+	"bytes"
+	"errors"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"strconv"
+	"time"
+)
+
+func AppendInput(b []byte, input [2]string) ([]byte, error) {
+	b = append(b, input[0]...)
+	b = append(b, '\t')
+	b = append(b, input[1]...)
+	b = append(b, '\n')
+	return b, nil
+}
+
+func ParseOutput(b []byte) (output [2]time.Time, err error) {
+	i := bytes.IndexRune(b, '\t')
+	if i == -1 {
+		return output, errors.New("unexpected output format")
+	}
+	for j, v := range [...][]byte{b[:i], b[i+1:]} {
+		if len(v) == 0 {
+			continue
+		}
+		output[j], err = baseline.ParseTimestamp(string(v))
+		if err != nil {
+			return output, err
+		}
+	}
+	return output, nil
+}
+
+// AppendInputID is a variant of [AppendInput], prefixing the input with a
+// tab-separated id, allowing pipelined commands to respond out of order.
+func AppendInputID(b []byte, id uint64, input [2]string) ([]byte, error) {
+	b = strconv.AppendUint(b, id, 10)
+	b = append(b, '\t')
+	return AppendInput(b, input)
+}
+
+// ParseOutputID is a variant of [ParseOutput], for output prefixed with a
+// tab-separated id, see [AppendInputID].
+func ParseOutputID(b []byte) (id uint64, output [2]time.Time, err error) {
+	i := bytes.IndexRune(b, '\t')
+	if i == -1 {
+		return id, output, errors.New("unexpected output format")
+	}
+	id, err = strconv.ParseUint(string(b[:i]), 10, 64)
+	if err != nil {
+		return id, output, err
+	}
+	output, err = ParseOutput(b[i+1:])
+	return id, output, err
+}
+
+func CallToConvert(call func(input [2]string) ([2]time.Time, error)) baseline.DateToTimestamp {
+	return func(startDate, endDate string) (startTime, endTime time.Time) {
+		v, err := call([2]string{startDate, endDate})
+		if err != nil {
+			panic(err)
+		}
+		return v[0], v[1]
+	}
+}
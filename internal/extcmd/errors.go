@@ -0,0 +1,73 @@
+package extcmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WorkerError is the failure of a worker, e.g. exiting, or writing invalid
+// output, see [Config.Run].
+type WorkerError struct {
+	// Worker is the index of the worker, per [Config.Workers].
+	Worker int
+	// Err is the cause of the failure.
+	Err error
+	// Stderr is the last lines written to stderr by the worker, if any,
+	// per [Config.StderrLines].
+	Stderr []string
+}
+
+// TimeoutError is the error of a call that exceeded [Config.CallTimeout].
+// The worker the input was sent to is considered to have failed, with this
+// error, as it may yet respond.
+type TimeoutError struct {
+	// Input is the input of the call.
+	Input any
+	// Encoded is the input, as it was written to the command.
+	Encoded []byte
+	// Elapsed is the time since the input was sent to the command.
+	Elapsed time.Duration
+	// Stderr is the last lines written to stderr by the command, if any,
+	// per [Config.StderrLines].
+	Stderr []string
+	// Buffering indicates the command read the input (and any prior), but
+	// wrote no output, since the input was sent. This is typically because
+	// the command is buffering its output, i.e. not flushing stdout after
+	// each output. It is only detected on Linux.
+	Buffering bool
+}
+
+func (x *TimeoutError) Error() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, `extcmd: call timed out after %s, input %q`, x.Elapsed, x.Encoded)
+	if x.Buffering {
+		b.WriteString(`: the command read the input, but wrote no output, it may be buffering its output (stdout must be flushed after each output)`)
+	}
+	writeStderr(&b, x.Stderr)
+	return b.String()
+}
+
+func (x *WorkerError) Error() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, `extcmd: worker %d: %s`, x.Worker, x.Err)
+	// N.B. a timeout includes the same lines
+	if _, ok := x.Err.(*TimeoutError); !ok {
+		writeStderr(&b, x.Stderr)
+	}
+	return b.String()
+}
+
+func (x *WorkerError) Unwrap() error {
+	return x.Err
+}
+
+func writeStderr(b *strings.Builder, lines []string) {
+	if len(lines) != 0 {
+		b.WriteString("\nstderr:")
+		for _, line := range lines {
+			b.WriteString("\n\t")
+			b.WriteString(line)
+		}
+	}
+}
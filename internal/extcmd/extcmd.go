@@ -3,10 +3,7 @@ package extcmd
 import (
 	"bufio"
 	"context"
-	"io"
 	"os"
-	"os/exec"
-	"sync"
 )
 
 // Run implements a closure using an external command, operating in a
@@ -18,6 +15,9 @@ import (
 // This output will then be parsed by the parseOutput function.
 // The f function will be called with the context and a function that can be
 // used to send input to the command, and receive output from the command.
+// Once f returns, the stdin of the command is closed, allowing it to exit
+// cleanly, see [Config.GracePeriod]. See also [Config.Run], which supports
+// pipelining, and checking the exit status.
 func Run[
 	// to closure
 	Input any,
@@ -37,103 +37,15 @@ func Run[
 	if calledOnEntry != nil {
 		calledOnEntry()
 	}
-
-	ctx, cancel := context.WithCancelCause(ctx)
-	defer cancel(nil)
-
-	rIn, wIn := io.Pipe()
-	defer rIn.Close()
-	rOut, wOut := io.Pipe()
-	defer rOut.Close()
-
-	inputs := make(chan []byte)
-	go func() {
-		defer func() { _ = wIn.CloseWithError(context.Cause(ctx)) }()
-		for ctx.Err() == nil {
-			select {
-			case <-ctx.Done():
-				return
-			case v := <-inputs:
-				if _, err := wIn.Write(v); err != nil {
-					cancel(err)
-					return
-				}
-			}
-		}
-	}()
-
-	outputs := make(chan Output)
-	go func() {
-		defer rOut.Close()
-		r := bufio.NewScanner(rOut)
-		r.Split(splitOutput)
-		for r.Scan() {
-			v, err := parseOutput(r.Bytes())
-			if err != nil {
-				cancel(err)
-				return
-			}
-			select {
-			case <-ctx.Done():
-				return
-			case outputs <- v:
-			}
-		}
-		if err := r.Err(); err != nil {
-			cancel(err)
-		}
-	}()
-
-	c := exec.CommandContext(ctx, command, args...)
-	c.Dir = dir
-	c.Stderr = os.Stderr
-	c.Stdin = rIn
-	c.Stdout = wOut
-
-	go func() {
-		defer rIn.Close()
-		defer wOut.Close()
-		var err error
-		defer func() {
-			cancel(err)
-			_ = wOut.CloseWithError(context.Cause(ctx))
-		}()
-		err = c.Run()
-	}()
-
-	var (
-		mu  sync.Mutex
-		buf []byte
-	)
-
-	call := func(input Input) (output Output, err error) {
-		mu.Lock()
-		defer mu.Unlock()
-
-		if ctx.Err() != nil {
-			return output, context.Cause(ctx)
-		}
-
-		buf = buf[:0]
-
-		buf, err = appendInput(buf, input)
-		if err != nil {
-			return output, err
-		}
-
-		select {
-		case <-ctx.Done():
-			return output, context.Cause(ctx)
-		case inputs <- buf:
-		}
-
-		select {
-		case <-ctx.Done():
-			return output, context.Cause(ctx)
-		case output = <-outputs:
-			return output, nil
-		}
-	}
-
-	return f(ctx, call)
+	return Config[Input, Output]{
+		Command:     command,
+		Args:        args,
+		Dir:         dir,
+		AppendInput: appendInput,
+		SplitOutput: splitOutput,
+		ParseOutput: parseOutput,
+		Stderr:      os.Stderr,
+	}.Run(ctx, func(ctx context.Context, client *Client[Input, Output]) error {
+		return f(ctx, client.Call)
+	})
 }
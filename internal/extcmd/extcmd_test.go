@@ -0,0 +1,507 @@
+package extcmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// helperEnv selects the behavior of this test binary, when run as the
+// external command, see helperProcess.
+const helperEnv = `EXTCMD_TEST_HELPER`
+
+func TestMain(m *testing.M) {
+	if v := os.Getenv(helperEnv); v != `` {
+		if err := helperProcess(v); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// helperProcess reads lines from stdin, and writes them to stdout, per mode:
+//
+//   - echo: unmodified
+//   - delay: unmodified, 100ms after reading each line, i.e. streaming
+//   - reverse N: in batches of N lines, in reverse order
+//   - unsolicited: unmodified, plus an extra line
+//   - exit: exits after reading the first line
+//   - buffer: writes to stderr, then never flushes stdout
+//   - hang: writes to stderr, then never reads stdin
+//   - stderr: unmodified, after writing it to stderr
+//   - crash PATH: unmodified, but exits on reading a "crash" line, unless
+//     PATH exists, which it creates, i.e. only the first time
+//   - flush: unmodified, but only flushed at EOF, plus an extra line, then
+//     exits with an error
+//   - ignore: unmodified, but never exits, after EOF
+func helperProcess(mode string) error {
+	switch mode {
+	case `buffer`, `hang`:
+		_, _ = fmt.Fprintf(os.Stderr, "%s\nmode\n", mode)
+	}
+	if mode == `hang` {
+		// N.B. not select {}, which the runtime detects as a deadlock
+		time.Sleep(time.Hour)
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	w := bufio.NewWriter(os.Stdout)
+	var mu sync.Mutex
+	write := func(lines ...string) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, line := range lines {
+			_, _ = w.WriteString(line + "\n")
+		}
+		_ = w.Flush()
+	}
+	var batch []string
+	// delayed lines, written in order
+	delayed := make(chan [2]any, 1024)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer close(delayed)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for v := range delayed {
+			time.Sleep(time.Until(v[1].(time.Time)))
+			write(v[0].(string))
+		}
+	}()
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case mode == `buffer`, mode == `flush`:
+			_, _ = w.WriteString(line + "\n")
+		case mode == `stderr`:
+			_, _ = fmt.Fprintln(os.Stderr, line)
+			write(line)
+		case mode == `echo`, mode == `ignore`:
+			write(line)
+		case mode == `delay`:
+			delayed <- [2]any{line, time.Now().Add(100 * time.Millisecond)}
+		case strings.HasPrefix(mode, `reverse `):
+			n, err := strconv.Atoi(strings.TrimPrefix(mode, `reverse `))
+			if err != nil {
+				return err
+			}
+			batch = append(batch, line)
+			if len(batch) == n {
+				slices.Reverse(batch)
+				write(batch...)
+				batch = batch[:0]
+			}
+		case mode == `unsolicited`:
+			write(line, `unsolicited`)
+		case mode == `exit`:
+			return errors.New(`exit`)
+		case strings.HasPrefix(mode, `crash `):
+			if path := strings.TrimPrefix(mode, `crash `); line == `crash` {
+				if _, err := os.Stat(path); err != nil {
+					_ = os.WriteFile(path, nil, 0o644)
+					return errors.New(`crash`)
+				}
+			}
+			write(line)
+		default:
+			return fmt.Errorf(`unknown mode: %s`, mode)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	switch mode {
+	case `flush`:
+		write(`eof`)
+		return errors.New(`flush`)
+	case `ignore`:
+		time.Sleep(time.Hour)
+	}
+	return nil
+}
+
+func helperConfig(t *testing.T, mode string) Config[string, string] {
+	t.Setenv(helperEnv, mode)
+	return Config[string, string]{
+		Command: os.Args[0],
+		AppendInput: func(b []byte, input string) ([]byte, error) {
+			if strings.Contains(input, "\n") {
+				return b, errors.New(`invalid input`)
+			}
+			return append(append(b, input...), '\n'), nil
+		},
+		SplitOutput: bufio.ScanLines,
+		ParseOutput: func(b []byte) (string, error) { return string(b), nil },
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Setenv(helperEnv, `echo`)
+	var entered bool
+	err := Run[string, string](
+		context.Background(),
+		func() { entered = true },
+		os.Args[0],
+		nil,
+		``,
+		func(b []byte, input string) ([]byte, error) { return append(append(b, input...), '\n'), nil },
+		bufio.ScanLines,
+		func(b []byte) (string, error) { return string(b), nil },
+		func(ctx context.Context, call func(input string) (string, error)) error {
+			for i := range 100 {
+				input := strconv.Itoa(i)
+				if output, err := call(input); err != nil || output != input {
+					return fmt.Errorf(`unexpected output: %q, %v`, output, err)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil || !entered {
+		t.Fatal(err, entered)
+	}
+}
+
+func TestConfig_Run_pipelined(t *testing.T) {
+	config := helperConfig(t, `delay`)
+	config.Window = 10
+	err := config.Run(context.Background(), func(ctx context.Context, client *Client[string, string]) error {
+		start := time.Now()
+		var futures []*Future[string]
+		for i := range 20 {
+			futures = append(futures, client.Go(strconv.Itoa(i)))
+		}
+		for i, future := range futures {
+			if output, err := future.Result(); err != nil || output != strconv.Itoa(i) {
+				return fmt.Errorf(`unexpected output %d: %q, %v`, i, output, err)
+			}
+		}
+		// two windows of 100ms, rather than 20 sequential calls
+		if d := time.Since(start); d > time.Second {
+			return fmt.Errorf(`not pipelined: took %s`, d)
+		}
+		if _, err := client.Call("a\nb"); err == nil || err.Error() != `invalid input` {
+			return fmt.Errorf(`unexpected error: %v`, err)
+		}
+		// the failed input must not hold a slot in the window
+		if output, err := client.Call(`c`); err != nil || output != `c` {
+			return fmt.Errorf(`unexpected output: %q, %v`, output, err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfig_Run_ids(t *testing.T) {
+	config := helperConfig(t, `reverse 4`)
+	config.Window = 4
+	config.AppendInputID = func(b []byte, id uint64, input string) ([]byte, error) {
+		b = strconv.AppendUint(b, id, 10)
+		b = append(b, '\t')
+		return config.AppendInput(b, input)
+	}
+	config.ParseOutputID = func(b []byte) (uint64, string, error) {
+		i := bytes.IndexByte(b, '\t')
+		if i == -1 {
+			return 0, ``, errors.New(`missing id`)
+		}
+		id, err := strconv.ParseUint(string(b[:i]), 10, 64)
+		return id, string(b[i+1:]), err
+	}
+	err := config.Run(context.Background(), func(ctx context.Context, client *Client[string, string]) error {
+		var futures []*Future[string]
+		for i := range 12 {
+			futures = append(futures, client.Go(strconv.Itoa(i)))
+		}
+		for i, future := range futures {
+			if output, err := future.Result(); err != nil || output != strconv.Itoa(i) {
+				return fmt.Errorf(`unexpected output %d: %q, %v`, i, output, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// FIFO order would mismatch the outputs
+	config.AppendInputID, config.ParseOutputID = nil, nil
+	err = config.Run(context.Background(), func(ctx context.Context, client *Client[string, string]) error {
+		a, b := client.Go(`a`), client.Go(`b`)
+		_, _ = client.Go(`c`), client.Go(`d`)
+		if output, err := a.Result(); err != nil || output != `d` {
+			return fmt.Errorf(`unexpected output: %q, %v`, output, err)
+		}
+		if output, err := b.Result(); err != nil || output != `c` {
+			return fmt.Errorf(`unexpected output: %q, %v`, output, err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfig_Run_failure(t *testing.T) {
+	for _, tc := range [...]struct {
+		mode string
+		err  string
+	}{
+		{`unsolicited`, `extcmd: worker 0: extcmd: unexpected output: "unsolicited"`},
+		{`exit`, "extcmd: worker 0: exit status 1\nstderr:\n\texit"},
+	} {
+		t.Run(tc.mode, func(t *testing.T) {
+			config := helperConfig(t, tc.mode)
+			config.Window = 2
+			config.MaxRestarts = -1
+			err := config.Run(context.Background(), func(ctx context.Context, client *Client[string, string]) error {
+				future := client.Go(`a`)
+				<-ctx.Done()
+				if err := context.Cause(ctx); err == nil || err.Error() != tc.err {
+					return fmt.Errorf(`unexpected cause: %v`, err)
+				}
+				select {
+				case <-future.Done():
+				case <-time.After(time.Second):
+					return errors.New(`expected future to be done`)
+				}
+				if _, err := client.Call(`b`); err != context.Cause(ctx) {
+					return fmt.Errorf(`unexpected error: %v`, err)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestConfig_Run_workers(t *testing.T) {
+	config := helperConfig(t, `delay`)
+	config.Workers = 4
+	err := config.Run(context.Background(), func(ctx context.Context, client *Client[string, string]) error {
+		start := time.Now()
+		var futures []*Future[string]
+		for i := range 8 {
+			futures = append(futures, client.Go(strconv.Itoa(i)))
+		}
+		for i, future := range futures {
+			if output, err := future.Result(); err != nil || output != strconv.Itoa(i) {
+				return fmt.Errorf(`unexpected output %d: %q, %v`, i, output, err)
+			}
+		}
+		// two rounds of 100ms, rather than 8 sequential calls
+		if d := time.Since(start); d > 600*time.Millisecond {
+			return fmt.Errorf(`not load balanced: took %s`, d)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfig_Run_restart(t *testing.T) {
+	for _, tc := range [...]struct {
+		name    string
+		retries int
+		err     string
+	}{
+		{`retry`, 1, ``},
+		{`no retry`, 0, "extcmd: worker 0: exit status 1\nstderr:\n\tcrash"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := helperConfig(t, `crash `+t.TempDir()+`/crashed`)
+			// N.B. restarts are enabled by default
+			config.RestartBackoff = time.Millisecond
+			config.Retries = tc.retries
+			err := config.Run(context.Background(), func(ctx context.Context, client *Client[string, string]) error {
+				output, err := client.Call(`crash`)
+				if tc.err != `` {
+					if err == nil || err.Error() != tc.err {
+						return fmt.Errorf(`unexpected error: %v`, err)
+					}
+				} else if err != nil || output != `crash` {
+					return fmt.Errorf(`unexpected output: %q, %v`, output, err)
+				}
+				// the restarted worker handles subsequent calls
+				for i := range 10 {
+					if output, err := client.Call(strconv.Itoa(i)); err != nil || output != strconv.Itoa(i) {
+						return fmt.Errorf(`unexpected output %d: %q, %v`, i, output, err)
+					}
+				}
+				return ctx.Err()
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestConfig_Run_timeout(t *testing.T) {
+	for _, tc := range [...]struct {
+		mode      string
+		buffering bool
+	}{
+		{`buffer`, runtime.GOOS == `linux`},
+		{`hang`, false},
+	} {
+		t.Run(tc.mode, func(t *testing.T) {
+			config := helperConfig(t, tc.mode)
+			config.MaxRestarts = -1
+			config.CallTimeout = 200 * time.Millisecond
+			err := config.Run(context.Background(), func(ctx context.Context, client *Client[string, string]) error {
+				// N.B. allows the command to start, and write to stderr
+				time.Sleep(100 * time.Millisecond)
+				_, err := client.Call(`a`)
+				var timeoutErr *TimeoutError
+				if !errors.As(err, &timeoutErr) {
+					return fmt.Errorf(`unexpected error: %v`, err)
+				}
+				if timeoutErr.Input != `a` || string(timeoutErr.Encoded) != "a\n" ||
+					timeoutErr.Elapsed < config.CallTimeout ||
+					!slices.Equal(timeoutErr.Stderr, []string{tc.mode, `mode`}) ||
+					timeoutErr.Buffering != tc.buffering {
+					return fmt.Errorf(`unexpected error: %#v`, timeoutErr)
+				}
+				<-ctx.Done()
+				var workerErr *WorkerError
+				if err := context.Cause(ctx); !errors.As(err, &workerErr) || workerErr.Err != timeoutErr {
+					return fmt.Errorf(`unexpected cause: %v`, err)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestConfig_Run_stop(t *testing.T) {
+	for _, tc := range [...]struct {
+		name   string
+		mode   string
+		config func(config *Config[string, string])
+		err    string
+	}{
+		{`flush`, `flush`, func(config *Config[string, string]) {}, ``},
+		{`check exit`, `flush`, func(config *Config[string, string]) { config.CheckExit = true }, "extcmd: worker 0: exit status 1\nstderr:\n\tflush"},
+		{`check unread output`, `flush`, func(config *Config[string, string]) { config.CheckUnreadOutput = true }, `extcmd: worker 0: extcmd: unread output: "eof"`},
+		{`grace period`, `ignore`, func(config *Config[string, string]) {
+			config.GracePeriod = 100 * time.Millisecond
+			config.CheckExit = true
+		}, `extcmd: worker 0: signal: interrupt`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := helperConfig(t, tc.mode)
+			config.Window = 2
+			tc.config(&config)
+			var futures []*Future[string]
+			start := time.Now()
+			err := config.Run(context.Background(), func(ctx context.Context, client *Client[string, string]) error {
+				futures = append(futures, client.Go(`a`), client.Go(`b`))
+				return nil
+			})
+			if tc.err == `` {
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf(`unexpected error: %v`, err)
+			}
+			if d := time.Since(start); d > 900*time.Millisecond {
+				t.Errorf(`not stopped promptly: took %s`, d)
+			}
+			// outputs written before the command exits complete the calls
+			for i, input := range [...]string{`a`, `b`} {
+				if output, err := futures[i].Result(); err != nil || output != input {
+					t.Errorf(`unexpected output %d: %q, %v`, i, output, err)
+				}
+			}
+		})
+	}
+}
+
+func TestTimeoutError_Error(t *testing.T) {
+	err := &TimeoutError{
+		Encoded:   []byte("a\tb\n"),
+		Elapsed:   time.Second,
+		Stderr:    []string{`x`, `y`},
+		Buffering: true,
+	}
+	if s := err.Error(); s != "extcmd: call timed out after 1s, input \"a\\tb\\n\": the command read the input, but wrote no output, it may be buffering its output (stdout must be flushed after each output)\nstderr:\n\tx\n\ty" {
+		t.Error(s)
+	}
+}
+
+func TestConfig_Run_stderr(t *testing.T) {
+	config := helperConfig(t, `stderr`)
+	var tee bytes.Buffer
+	config.Stderr = &tee
+	config.StderrPrefix = `[stderr] `
+	config.StderrLines = 2
+	var futures []*Future[string]
+	err := config.Run(context.Background(), func(ctx context.Context, client *Client[string, string]) error {
+		for i := range 3 {
+			future := client.Go(strconv.Itoa(i))
+			if output, err := future.Result(); err != nil || output != strconv.Itoa(i) {
+				return fmt.Errorf(`unexpected output %d: %q, %v`, i, output, err)
+			}
+			futures = append(futures, future)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// N.B. attribution is best effort, without syncStderr
+	for i, future := range futures {
+		if runtime.GOOS != `linux` {
+			break
+		}
+		if lines := future.Stderr(); !slices.Equal(lines, []string{strconv.Itoa(i)}) {
+			t.Errorf(`unexpected stderr %d: %q`, i, lines)
+		}
+	}
+	if s := tee.String(); s != "[stderr] 0\n[stderr] 1\n[stderr] 2\n" {
+		t.Errorf(`unexpected tee: %q`, s)
+	}
+}
+
+func TestStderr(t *testing.T) {
+	ring := newStderrRing(3)
+	w := stderrWriter{line: ring.add}
+	_, _ = w.Write([]byte("a\nb"))
+	if lines := ring.Lines(); !slices.Equal(lines, []string{`a`}) {
+		t.Fatal(lines)
+	}
+	_, _ = w.Write([]byte("c\nd\ne\nf"))
+	w.flush()
+	if lines := ring.Lines(); !slices.Equal(lines, []string{`d`, `e`, `f`}) {
+		t.Fatal(lines)
+	}
+	err := fmt.Errorf(`wrapped: %w`, &WorkerError{Err: errors.New(`failed`), Stderr: ring.Lines()})
+	if lines := Stderr(err); !slices.Equal(lines, []string{`d`, `e`, `f`}) {
+		t.Fatal(lines)
+	}
+	if lines := Stderr(errors.New(`other`)); lines != nil {
+		t.Fatal(lines)
+	}
+}
@@ -0,0 +1,840 @@
+package extcmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultMaxRestarts is the default of [Config.MaxRestarts].
+	defaultMaxRestarts = 3
+
+	// defaultRestartBackoff is the default of [Config.RestartBackoff].
+	defaultRestartBackoff = 100 * time.Millisecond
+
+	// maxRestartBackoff caps the backoff between restarts of a worker.
+	maxRestartBackoff = 10 * time.Second
+
+	// defaultGracePeriod is the default of [Config.GracePeriod].
+	defaultGracePeriod = time.Second
+
+	// defaultKillDelay is the default of [Config.KillDelay].
+	defaultKillDelay = time.Second
+)
+
+type (
+	// Config configures an external command, see [Config.Run], which
+	// supports pipelining, i.e. many inputs in flight at once, unlike the
+	// ping-pong fashion of [Run], and a pool of worker processes.
+	Config[Input any, Output any] struct {
+		Command string
+		Args    []string
+		Dir     string
+
+		// AppendInput, SplitOutput and ParseOutput are as per [Run].
+		AppendInput func(b []byte, input Input) ([]byte, error)
+		SplitOutput bufio.SplitFunc
+		ParseOutput func(b []byte) (Output, error)
+
+		// AppendInputID and ParseOutputID, if set, are used instead of
+		// AppendInput and ParseOutput, to tag each input with a unique ID,
+		// which the command must include in the corresponding output. This
+		// allows the command to respond out of order. Otherwise, outputs are
+		// matched to inputs in FIFO order.
+		AppendInputID func(b []byte, id uint64, input Input) ([]byte, error)
+		ParseOutputID func(b []byte) (id uint64, output Output, err error)
+
+		// Window is the maximum number of inputs in flight, per worker, i.e.
+		// sent to the command, without a corresponding output. Defaults to
+		// 1, i.e. ping-pong.
+		Window int
+
+		// Workers is the number of instances of the command to run, each
+		// input being sent to the least busy. Defaults to 1.
+		Workers int
+
+		// MaxRestarts is the number of times that workers may be restarted,
+		// in total, after failing, e.g. exiting, or writing invalid output.
+		// Once exhausted, the failure of any worker fails the run, i.e. the
+		// context passed to the f of [Config.Run] is canceled, with the
+		// failure as the cause. Defaults to 3. Negative disables restarts,
+		// i.e. the first failure fails the run.
+		MaxRestarts int
+
+		// RestartBackoff is the delay before restarting a failed worker,
+		// doubled for each consecutive failure of the same worker. Defaults
+		// to 100ms.
+		RestartBackoff time.Duration
+
+		// Retries is the number of times that an input in flight may be
+		// resent (to any worker), after the worker it was sent to fails.
+		// Once exhausted, the call fails, with the failure of the worker.
+		// Defaults to 0.
+		Retries int
+
+		// CallTimeout, if positive, is the maximum time to wait for the
+		// output, after sending the input to a worker. On timeout, the call
+		// fails with a [*TimeoutError], without being retried, and the worker
+		// is considered to have failed, with the same error.
+		CallTimeout time.Duration
+
+		// Stderr, if set, receives each line written to stderr by the
+		// workers, prefixed by StderrPrefix. Regardless, the last lines are
+		// retained, per worker, see StderrLines, and each line is attributed
+		// to a call, where possible, see [Future.Stderr].
+		Stderr       io.Writer
+		StderrPrefix string
+
+		// StderrLines is the number of lines of stderr retained, per worker,
+		// for [WorkerError] and [TimeoutError]. Defaults to 10.
+		StderrLines int
+
+		// GracePeriod is the time to wait for each worker to exit, after its
+		// stdin is closed, once the f of [Config.Run] returns, before it is
+		// interrupted. Defaults to 1s.
+		GracePeriod time.Duration
+
+		// KillDelay is the time to wait for a worker to exit, after it is
+		// interrupted, e.g. after the GracePeriod, or on failure, before it
+		// is killed. Defaults to 1s.
+		KillDelay time.Duration
+
+		// CheckExit, if set, fails the run if any worker exits with an
+		// error, e.g. a non-zero exit code, or being interrupted, after the
+		// GracePeriod, once its stdin is closed.
+		CheckExit bool
+
+		// CheckUnreadOutput, if set, fails the run if any worker writes
+		// output that doesn't correspond to a call, once its stdin is
+		// closed. Otherwise, such output is discarded.
+		CheckUnreadOutput bool
+	}
+
+	// Client sends inputs to the workers, see [Config.Run]. It is safe for
+	// concurrent use.
+	Client[Input any, Output any] struct {
+		ctx    context.Context
+		cancel context.CancelCauseFunc
+		config *Config[Input, Output]
+		wg     sync.WaitGroup
+		// runs tracks the workers (running the command), see stop
+		runs sync.WaitGroup
+		// stderr is nil unless [Config.Stderr] is set
+		stderr *prefixWriter
+		// window has a slot for each call in progress, for all workers
+		window chan struct{}
+
+		mu       sync.Mutex
+		workers  []*worker[Input, Output]
+		backlog  []*call[Input, Output]
+		restarts int
+		err      error
+		// stopping is set once f returns, see stop, and stopErrs are the
+		// failures of the run, per [Config.CheckExit] and
+		// [Config.CheckUnreadOutput]
+		stopping bool
+		stopErrs []error
+	}
+
+	// Future is the eventual result of [Client.Go].
+	Future[Output any] struct {
+		done   chan struct{}
+		output Output
+		err    error
+		// mu is the lock of the client, which guards stderr
+		mu     *sync.Mutex
+		stderr []string
+	}
+
+	// worker is an instance of the command, which is replaced on restart.
+	worker[Input any, Output any] struct {
+		index  int
+		cancel context.CancelCauseFunc
+		// writes is the encoded inputs, not yet written, and notify
+		// signals the writer that there are more
+		writes   [][]byte
+		notify   chan struct{}
+		nextID   uint64
+		queue    []*call[Input, Output]
+		pending  map[uint64]*call[Input, Output]
+		failures int
+		// stdin is the pipe to the command, stderr retains its last lines,
+		// and read counts the bytes of its output, for [TimeoutError]
+		stdin  *os.File
+		stderr *stderrRing
+		read   atomic.Int64
+		// stderrPipe is read by readStderr, which signals stderrNotify
+		// after each read, see syncStderr
+		stderrPipe   *os.File
+		stderrNotify chan struct{}
+		// last is the last call sent to the command, see [Future.Stderr]
+		last *call[Input, Output]
+		// ready is set once the command has started, and done once it has
+		// failed, or been stopped
+		ready, done bool
+		// closing is set, and closeStdin closed, to close stdin, after any
+		// remaining writes, once there are no more calls, see closeIdle
+		closing    bool
+		closeStdin chan struct{}
+		// grace interrupts the worker, after the GracePeriod
+		grace *time.Timer
+		// unreadOutput is set once unread output is reported, see unread
+		unreadOutput bool
+	}
+
+	call[Input any, Output any] struct {
+		input    Input
+		future   *Future[Output]
+		attempts int
+		// the following are set on sending the input to a worker
+		worker  *worker[Input, Output]
+		id      uint64
+		encoded []byte
+		sent    time.Time
+		read    int64
+		timer   *time.Timer
+	}
+
+	// countingReader counts the bytes read, see worker.read.
+	countingReader struct {
+		r io.Reader
+		n *atomic.Int64
+	}
+)
+
+// Run starts the worker(s), calls f with a client, which may be used to send
+// inputs to the workers, then stops the workers, once f returns. As with
+// [Run], if a worker fails, e.g. exits, or writes invalid output, the
+// context passed to f is canceled, with the failure as the cause, and any
+// calls in flight fail, unless the worker may be restarted, see
+// [Config.MaxRestarts]. Output that doesn't correspond to an input in flight
+// is also considered a failure.
+//
+// The workers are stopped by closing their stdin, then waiting for them to
+// exit, see [Config.GracePeriod]. The error returned by f is returned, or, if
+// nil, any failures per [Config.CheckExit] and [Config.CheckUnreadOutput].
+func (x Config[Input, Output]) Run(ctx context.Context, f func(ctx context.Context, client *Client[Input, Output]) error) error {
+	if (x.AppendInputID == nil) != (x.ParseOutputID == nil) {
+		return errors.New(`extcmd: AppendInputID and ParseOutputID must be set together`)
+	}
+	if x.AppendInputID == nil && (x.AppendInput == nil || x.ParseOutput == nil) {
+		return errors.New(`extcmd: AppendInput and ParseOutput must be set`)
+	}
+	if x.SplitOutput == nil {
+		return errors.New(`extcmd: SplitOutput must be set`)
+	}
+	x.Window = max(x.Window, 1)
+	x.Workers = max(x.Workers, 1)
+	switch {
+	case x.MaxRestarts == 0:
+		x.MaxRestarts = defaultMaxRestarts
+	case x.MaxRestarts < 0:
+		x.MaxRestarts = 0
+	}
+	if x.RestartBackoff <= 0 {
+		x.RestartBackoff = defaultRestartBackoff
+	}
+	if x.StderrLines <= 0 {
+		x.StderrLines = defaultStderrLines
+	}
+	if x.GracePeriod <= 0 {
+		x.GracePeriod = defaultGracePeriod
+	}
+	if x.KillDelay <= 0 {
+		x.KillDelay = defaultKillDelay
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	client := &Client[Input, Output]{
+		ctx:     ctx,
+		cancel:  cancel,
+		config:  &x,
+		window:  make(chan struct{}, x.Window*x.Workers),
+		workers: make([]*worker[Input, Output], x.Workers),
+	}
+	if x.Stderr != nil {
+		client.stderr = &prefixWriter{w: x.Stderr, prefix: x.StderrPrefix}
+	}
+	defer func() {
+		// N.B. the workers must be stopped before waiting
+		cancel(nil)
+		client.runs.Wait()
+		client.wg.Wait()
+	}()
+
+	client.mu.Lock()
+	for i := range client.workers {
+		client.start(i, 0)
+	}
+	client.mu.Unlock()
+
+	client.wg.Add(1)
+	go func() {
+		defer client.wg.Done()
+		<-ctx.Done()
+		client.close(context.Cause(ctx))
+	}()
+
+	err := f(ctx, client)
+	if stopErr := client.stop(); err == nil {
+		err = stopErr
+	}
+	return err
+}
+
+// Call sends input to a worker, and waits for the output.
+func (x *Client[Input, Output]) Call(input Input) (Output, error) {
+	return x.Go(input).Result()
+}
+
+// Go sends input to a worker, returning a future for the output. It blocks
+// while the window of every worker is full.
+func (x *Client[Input, Output]) Go(input Input) *Future[Output] {
+	c := &call[Input, Output]{
+		input:  input,
+		future: &Future[Output]{done: make(chan struct{}), mu: &x.mu},
+	}
+
+	select {
+	case <-x.ctx.Done():
+		c.future.resolve(*new(Output), context.Cause(x.ctx))
+		return c.future
+	case x.window <- struct{}{}:
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if x.err != nil {
+		x.complete(c, *new(Output), x.err)
+		return c.future
+	}
+
+	x.backlog = append(x.backlog, c)
+	x.dispatch()
+
+	return c.future
+}
+
+// start starts (or restarts) the worker at index i, after the delay. It must
+// be called with the lock held.
+func (x *Client[Input, Output]) start(i int, delay time.Duration) {
+	ctx, cancel := context.WithCancelCause(x.ctx)
+	w := &worker[Input, Output]{
+		index:   i,
+		cancel:  cancel,
+		notify:  make(chan struct{}, 1),
+		pending: make(map[uint64]*call[Input, Output]),
+		stderr:  newStderrRing(x.config.StderrLines),
+		// N.B. buffered, so readStderr never blocks
+		stderrNotify: make(chan struct{}, 1),
+		closeStdin:   make(chan struct{}),
+	}
+	if prev := x.workers[i]; prev != nil {
+		w.failures = prev.failures
+	}
+	x.workers[i] = w
+
+	x.runs.Add(1)
+	go func() {
+		defer x.runs.Done()
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+		}
+		x.run(ctx, w)
+	}()
+}
+
+// run runs the command, for the worker, until it fails, or is stopped.
+func (x *Client[Input, Output]) run(ctx context.Context, w *worker[Input, Output]) {
+	c := exec.CommandContext(ctx, x.config.Command, x.config.Args...)
+	c.Dir = x.config.Dir
+	c.Cancel = func() error {
+		// N.B. killed after the WaitDelay, if it doesn't exit
+		if err := c.Process.Signal(os.Interrupt); err != nil {
+			return c.Process.Kill()
+		}
+		return nil
+	}
+	c.WaitDelay = x.config.KillDelay
+
+	// N.B. an os pipe (rather than io.Pipe) is necessary, to avoid the
+	// command being unable to be waited on, if it exits before reading, and
+	// to check whether it has read its input, see [TimeoutError.Buffering]
+	rIn, wIn, err := os.Pipe()
+	if err != nil {
+		x.fail(w, err)
+		return
+	}
+	// N.B. similarly, to check for unread stderr, see syncStderr
+	rErr, wErr, err := os.Pipe()
+	if err != nil {
+		_, _ = rIn.Close(), wIn.Close()
+		x.fail(w, err)
+		return
+	}
+	c.Stdin, c.Stderr = rIn, wErr
+	rOut, wOut := io.Pipe()
+	defer rOut.Close()
+	c.Stdout = wOut
+
+	err = c.Start()
+	_, _ = rIn.Close(), wErr.Close()
+	if err != nil {
+		_, _ = wIn.Close(), rErr.Close()
+		x.fail(w, err)
+		return
+	}
+
+	stderr := make(chan struct{})
+	go func() {
+		defer close(stderr)
+		defer rErr.Close()
+		x.readStderr(w, rErr)
+	}()
+
+	x.mu.Lock()
+	w.stdin, w.stderrPipe = wIn, rErr
+	w.ready = !w.done
+	x.dispatch()
+	x.mu.Unlock()
+
+	x.wg.Add(1)
+	go func() {
+		defer x.wg.Done()
+		defer func() { _ = wIn.Close() }()
+		x.write(ctx, w, wIn)
+	}()
+
+	read := make(chan struct{})
+	go func() {
+		defer close(read)
+		// N.B. closed on return, so the command's output is never blocked
+		defer rOut.Close()
+		x.read(w, countingReader{rOut, &w.read})
+	}()
+
+	err = c.Wait()
+	<-stderr
+	// N.B. any remaining output is read, before handling the exit
+	_ = wOut.Close()
+	<-read
+	x.exited(w, err)
+}
+
+// write writes the encoded inputs to the worker, until it is stopped.
+func (x *Client[Input, Output]) write(ctx context.Context, w *worker[Input, Output], wIn io.Writer) {
+	for {
+		var closing bool
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.notify:
+		case <-w.closeStdin:
+			closing = true
+		}
+		x.mu.Lock()
+		writes := w.writes
+		w.writes = nil
+		x.mu.Unlock()
+		for _, b := range writes {
+			if _, err := wIn.Write(b); err != nil {
+				x.fail(w, err)
+				return
+			}
+		}
+		if closing {
+			return
+		}
+	}
+}
+
+// read matches each output of the worker to the corresponding call, until
+// the output is exhausted, or invalid.
+func (x *Client[Input, Output]) read(w *worker[Input, Output], r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(x.config.SplitOutput)
+	for scanner.Scan() {
+		var (
+			id     uint64
+			output Output
+			err    error
+		)
+		if x.config.ParseOutputID != nil {
+			id, output, err = x.config.ParseOutputID(scanner.Bytes())
+		} else {
+			output, err = x.config.ParseOutput(scanner.Bytes())
+		}
+		if err != nil {
+			if x.unread(w, scanner.Bytes()) {
+				continue
+			}
+			x.fail(w, err)
+			return
+		}
+
+		// N.B. so stderr written before the output is attributed to the call
+		x.syncStderr(w)
+
+		x.mu.Lock()
+		done := w.done
+		var c *call[Input, Output]
+		if done {
+			// N.B. the calls have already been retried, or failed
+		} else if x.config.ParseOutputID != nil {
+			c = w.pending[id]
+			delete(w.pending, id)
+		} else if len(w.queue) != 0 {
+			c = w.queue[0]
+			w.queue[0] = nil
+			w.queue = w.queue[1:]
+		}
+		if c != nil {
+			w.failures = 0
+			x.complete(c, output, nil)
+			x.dispatch()
+		}
+		x.mu.Unlock()
+
+		switch {
+		case done:
+			return
+		case c != nil:
+		case x.unread(w, scanner.Bytes()):
+		case x.config.ParseOutputID != nil:
+			x.fail(w, fmt.Errorf(`extcmd: unexpected output for id %d: %q`, id, scanner.Bytes()))
+			return
+		default:
+			x.fail(w, fmt.Errorf(`extcmd: unexpected output: %q`, scanner.Bytes()))
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		x.fail(w, err)
+	}
+}
+
+// fail stops the worker, retrying or failing its calls in flight, then
+// restarts it, or fails the run, if there are no restarts remaining.
+func (x *Client[Input, Output]) fail(w *worker[Input, Output], err error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.failLocked(w, err)
+}
+
+// failLocked is [Client.fail], with the lock held.
+func (x *Client[Input, Output]) failLocked(w *worker[Input, Output], err error) {
+	if w.done || x.ctx.Err() != nil {
+		// already failed, or stopping
+		return
+	}
+	w.ready, w.done = false, true
+	w.failures++
+	err = &WorkerError{Worker: w.index, Err: err, Stderr: w.stderr.Lines()}
+	w.cancel(err)
+
+	calls := w.queue
+	for _, c := range w.pending {
+		calls = append(calls, c)
+	}
+	w.writes, w.queue, w.pending = nil, nil, nil
+	var retries []*call[Input, Output]
+	for _, c := range calls {
+		if c.attempts <= x.config.Retries {
+			c.stop()
+			retries = append(retries, c)
+		} else {
+			x.complete(c, *new(Output), err)
+		}
+	}
+	x.backlog = append(retries, x.backlog...)
+
+	if x.restarts >= x.config.MaxRestarts {
+		x.cancel(err)
+		return
+	}
+	x.restarts++
+	backoff := x.config.RestartBackoff
+	for i := 1; i < w.failures && backoff < maxRestartBackoff; i++ {
+		backoff *= 2
+	}
+	x.start(w.index, min(backoff, maxRestartBackoff))
+	x.dispatch()
+}
+
+// dispatch sends calls from the backlog to the least busy workers, while
+// they have capacity. It must be called with the lock held.
+func (x *Client[Input, Output]) dispatch() {
+	if x.stopping {
+		defer x.closeIdle()
+	}
+	for len(x.backlog) != 0 {
+		var w *worker[Input, Output]
+		for _, v := range x.workers {
+			if v.ready && !v.closing && v.inFlight() < x.config.Window && (w == nil || v.inFlight() < w.inFlight()) {
+				w = v
+			}
+		}
+		if w == nil {
+			return
+		}
+
+		c := x.backlog[0]
+		x.backlog[0] = nil
+		x.backlog = x.backlog[1:]
+		c.attempts++
+
+		var (
+			b   []byte
+			err error
+		)
+		if x.config.AppendInputID != nil {
+			c.id = w.nextID
+			b, err = x.config.AppendInputID(nil, c.id, c.input)
+			if err == nil {
+				w.nextID++
+				w.pending[c.id] = c
+			}
+		} else {
+			b, err = x.config.AppendInput(nil, c.input)
+			if err == nil {
+				w.queue = append(w.queue, c)
+			}
+		}
+		if err != nil {
+			x.complete(c, *new(Output), err)
+			continue
+		}
+
+		c.worker, c.encoded, c.sent, c.read = w, b, time.Now(), w.read.Load()
+		w.last = c
+		if x.config.CallTimeout > 0 {
+			c.timer = time.AfterFunc(x.config.CallTimeout, func() { x.timeout(c, w) })
+		}
+		w.writes = append(w.writes, b)
+		select {
+		case w.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// timeout fails the call, and the worker, if the call is still in flight on
+// the worker, see [Config.CallTimeout].
+func (x *Client[Input, Output]) timeout(c *call[Input, Output], w *worker[Input, Output]) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if c.worker != w || w.done {
+		return
+	}
+	if x.config.ParseOutputID != nil {
+		if w.pending[c.id] != c {
+			return
+		}
+		delete(w.pending, c.id)
+	} else {
+		i := slices.Index(w.queue, c)
+		if i == -1 {
+			return
+		}
+		w.queue = slices.Delete(w.queue, i, i+1)
+	}
+
+	err := &TimeoutError{
+		Input:   c.input,
+		Encoded: c.encoded,
+		Elapsed: time.Since(c.sent),
+		Stderr:  w.stderr.Lines(),
+	}
+	if w.read.Load() == c.read && len(w.writes) == 0 {
+		n, ok := unread(w.stdin)
+		err.Buffering = ok && n == 0
+	}
+	x.complete(c, *new(Output), err)
+	x.failLocked(w, err)
+}
+
+// stop waits for the backlog to be sent, then closes the stdin of each
+// worker, waiting for them to exit, see [Config.GracePeriod], returning the
+// failures of the run, if any.
+func (x *Client[Input, Output]) stop() error {
+	x.mu.Lock()
+	x.stopping = true
+	x.dispatch()
+	x.mu.Unlock()
+
+	x.runs.Wait()
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return errors.Join(x.stopErrs...)
+}
+
+// closeIdle closes the stdin of each worker, once the backlog is empty, and
+// stops any workers not yet started. It must be called with the lock held.
+func (x *Client[Input, Output]) closeIdle() {
+	if len(x.backlog) != 0 {
+		return
+	}
+	for _, w := range x.workers {
+		switch {
+		case w.done || w.closing:
+		case w.ready:
+			w.closing = true
+			close(w.closeStdin)
+			w.grace = time.AfterFunc(x.config.GracePeriod, func() {
+				w.cancel(fmt.Errorf(`extcmd: worker %d: grace period exceeded`, w.index))
+			})
+		default:
+			// N.B. starting, or restarting, and has no calls
+			w.done = true
+			w.cancel(context.Canceled)
+		}
+	}
+}
+
+// exited handles the exit of the worker, which is a failure, unless it
+// exited after its stdin was closed, see closeIdle.
+func (x *Client[Input, Output]) exited(w *worker[Input, Output], err error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if w.grace != nil {
+		w.grace.Stop()
+	}
+	if !w.closing {
+		if err == nil {
+			// no further output is possible
+			err = io.ErrUnexpectedEOF
+		}
+		x.failLocked(w, err)
+		return
+	}
+	if w.done {
+		return
+	}
+	w.ready, w.done = false, true
+	w.cancel(context.Canceled)
+
+	if err != nil && x.config.CheckExit {
+		x.stopErrs = append(x.stopErrs, &WorkerError{Worker: w.index, Err: err, Stderr: w.stderr.Lines()})
+	}
+
+	// N.B. any calls in flight can't complete
+	if err == nil {
+		err = io.ErrUnexpectedEOF
+	}
+	err = &WorkerError{Worker: w.index, Err: err, Stderr: w.stderr.Lines()}
+	for _, c := range w.queue {
+		x.complete(c, *new(Output), err)
+	}
+	for _, c := range w.pending {
+		x.complete(c, *new(Output), err)
+	}
+	w.writes, w.queue, w.pending = nil, nil, nil
+}
+
+// unread handles output that doesn't correspond to a call, after stdin was
+// closed, returning false if stdin wasn't closed, i.e. it is a failure.
+func (x *Client[Input, Output]) unread(w *worker[Input, Output], b []byte) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if !w.closing {
+		return false
+	}
+	if x.config.CheckUnreadOutput && !w.unreadOutput {
+		w.unreadOutput = true
+		x.stopErrs = append(x.stopErrs, &WorkerError{Worker: w.index, Err: fmt.Errorf(`extcmd: unread output: %q`, b), Stderr: w.stderr.Lines()})
+	}
+	return true
+}
+
+// close fails all calls in progress, and any subsequent calls.
+func (x *Client[Input, Output]) close(err error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.err = err
+	for _, c := range x.backlog {
+		x.complete(c, *new(Output), err)
+	}
+	x.backlog = nil
+	for _, w := range x.workers {
+		for _, c := range w.queue {
+			x.complete(c, *new(Output), err)
+		}
+		for _, c := range w.pending {
+			x.complete(c, *new(Output), err)
+		}
+		w.queue, w.pending = nil, nil
+	}
+}
+
+// complete resolves the future of a call, which must hold a slot in the
+// window.
+func (x *Client[Input, Output]) complete(c *call[Input, Output], output Output, err error) {
+	c.stop()
+	c.future.resolve(output, err)
+	<-x.window
+}
+
+func (x *worker[Input, Output]) inFlight() int {
+	return len(x.queue) + len(x.pending)
+}
+
+// stop stops the timer of the call, if any.
+func (x *call[Input, Output]) stop() {
+	if x.timer != nil {
+		x.timer.Stop()
+		x.timer = nil
+	}
+}
+
+func (x countingReader) Read(b []byte) (int, error) {
+	n, err := x.r.Read(b)
+	x.n.Add(int64(n))
+	return n, err
+}
+
+// Stderr returns the lines written to stderr by the command, attributed to
+// the call, i.e. while it was the only call in flight, on the worker, or
+// after it completed, until the next call was sent. Lines may be attributed
+// after Done is closed, as stderr is read independently of the output.
+func (x *Future[Output]) Stderr() []string {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return append([]string(nil), x.stderr...)
+}
+
+// Done is closed once the result is available.
+func (x *Future[Output]) Done() <-chan struct{} {
+	return x.done
+}
+
+// Result waits for, then returns, the output, or the error.
+func (x *Future[Output]) Result() (Output, error) {
+	<-x.done
+	return x.output, x.err
+}
+
+func (x *Future[Output]) resolve(output Output, err error) {
+	x.output, x.err = output, err
+	close(x.done)
+}
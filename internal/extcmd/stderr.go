@@ -0,0 +1,183 @@
+package extcmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultStderrLines is the default of [Config.StderrLines].
+	defaultStderrLines = 10
+
+	// stderrSyncTimeout bounds the wait for unread stderr, see syncStderr.
+	stderrSyncTimeout = 100 * time.Millisecond
+)
+
+type (
+	// stderrRing retains the last lines written to stderr, by a worker.
+	stderrRing struct {
+		lines []string
+		next  int
+		full  bool
+	}
+
+	// stderrWriter splits the stderr of a worker into lines, passing each
+	// to line, and any incomplete line on flush.
+	stderrWriter struct {
+		partial []byte
+		line    func(line string)
+	}
+
+	// prefixWriter serializes writes of lines, each with a prefix.
+	prefixWriter struct {
+		mu     sync.Mutex
+		w      io.Writer
+		prefix string
+	}
+)
+
+// Stderr returns the lines of stderr attached to err, by a [*TimeoutError]
+// or [*WorkerError], if any, e.g. to attach to a failure report.
+func Stderr(err error) []string {
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return timeoutErr.Stderr
+	}
+	var workerErr *WorkerError
+	if errors.As(err, &workerErr) {
+		return workerErr.Stderr
+	}
+	return nil
+}
+
+// readStderr reads the stderr of the worker, until EOF, see stderrLines.
+func (x *Client[Input, Output]) readStderr(w *worker[Input, Output], r io.Reader) {
+	var lines []string
+	sw := stderrWriter{line: func(line string) { lines = append(lines, line) }}
+	b := make([]byte, 4096)
+	for {
+		n, err := r.Read(b)
+		_, _ = sw.Write(b[:n])
+		if err != nil {
+			sw.flush()
+		}
+		x.stderrLines(w, lines)
+		lines = lines[:0]
+		select {
+		case w.stderrNotify <- struct{}{}:
+		default:
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// stderrLines handles lines written to stderr by the worker, attributing
+// them to the call in flight, if there is exactly one, or the last call
+// sent, if there are none, as stderr may be read after the output.
+func (x *Client[Input, Output]) stderrLines(w *worker[Input, Output], lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	if x.stderr != nil {
+		for _, line := range lines {
+			x.stderr.writeLine(line)
+		}
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	var c *call[Input, Output]
+	switch {
+	case w.inFlight() == 0:
+		c = w.last
+	case len(w.queue) == 1 && len(w.pending) == 0:
+		c = w.queue[0]
+	case len(w.queue) == 0 && len(w.pending) == 1:
+		for _, v := range w.pending {
+			c = v
+		}
+	}
+	for _, line := range lines {
+		w.stderr.add(line)
+		if c != nil {
+			c.future.stderr = append(c.future.stderr, line)
+		}
+	}
+}
+
+// syncStderr waits (briefly) for readStderr to read any stderr written by
+// the worker, so it is attributed before the next output is handled. This is
+// only possible on Linux, see unread.
+func (x *Client[Input, Output]) syncStderr(w *worker[Input, Output]) {
+	var timeout <-chan time.Time
+	for {
+		if n, ok := unread(w.stderrPipe); !ok || n == 0 {
+			return
+		}
+		if timeout == nil {
+			timer := time.NewTimer(stderrSyncTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case <-w.stderrNotify:
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func newStderrRing(n int) *stderrRing {
+	return &stderrRing{lines: make([]string, n)}
+}
+
+func (x *stderrRing) add(line string) {
+	if len(x.lines) == 0 {
+		return
+	}
+	x.lines[x.next] = line
+	x.next = (x.next + 1) % len(x.lines)
+	x.full = x.full || x.next == 0
+}
+
+// Lines returns a copy of the retained lines, oldest first.
+func (x *stderrRing) Lines() []string {
+	if !x.full {
+		return append([]string(nil), x.lines[:x.next]...)
+	}
+	return append(append([]string(nil), x.lines[x.next:]...), x.lines[:x.next]...)
+}
+
+func (x *stderrWriter) Write(b []byte) (int, error) {
+	n := len(b)
+	for {
+		i := bytes.IndexByte(b, '\n')
+		if i == -1 {
+			break
+		}
+		x.line(string(append(x.partial, b[:i]...)))
+		x.partial = x.partial[:0]
+		b = b[i+1:]
+	}
+	x.partial = append(x.partial, b...)
+	return n, nil
+}
+
+func (x *stderrWriter) flush() {
+	if len(x.partial) != 0 {
+		x.line(string(x.partial))
+		x.partial = x.partial[:0]
+	}
+}
+
+func (x *prefixWriter) writeLine(line string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	_, _ = io.WriteString(x.w, x.prefix+line+"\n")
+}
@@ -0,0 +1,27 @@
+package extcmd
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// unread returns the number of bytes written to the pipe, but not yet read,
+// e.g. by the command, from stdin.
+func unread(f *os.File) (int, bool) {
+	conn, err := f.SyscallConn()
+	if err != nil {
+		return 0, false
+	}
+	var (
+		n     int32
+		errno syscall.Errno
+	)
+	if err := conn.Control(func(fd uintptr) {
+		// N.B. TIOCINQ is FIONREAD, which is supported for pipes
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCINQ, uintptr(unsafe.Pointer(&n)))
+	}); err != nil || errno != 0 {
+		return 0, false
+	}
+	return int(n), true
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package extcmd
+
+import (
+	"os"
+)
+
+// unread is unsupported on this platform, see the Linux implementation.
+func unread(f *os.File) (int, bool) {
+	return 0, false
+}
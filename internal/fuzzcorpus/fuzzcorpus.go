@@ -0,0 +1,278 @@
+// Package fuzzcorpus reads and writes the corpus file format used by go test
+// fuzzing, i.e. testdata/fuzz/FuzzXxx/* files, which start with a
+// "go test fuzz v1" header, followed by one Go literal per line, e.g.
+// "int64(1706745599000000078)".
+package fuzzcorpus
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Header is the first line of every corpus file.
+const Header = `go test fuzz v1`
+
+// Entry is a single corpus file.
+type Entry struct {
+	// Name is the base name of the file.
+	Name string
+	// Values are the arguments to the fuzz function, with the same types.
+	Values []any
+}
+
+// Parse reads the values of a corpus file.
+func Parse(r io.Reader) ([]any, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<26)
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New(`fuzzcorpus: empty file`)
+	}
+	if s.Text() != Header {
+		return nil, fmt.Errorf(`fuzzcorpus: unexpected header %q`, s.Text())
+	}
+	var values []any
+	for line := 2; s.Scan(); line++ {
+		text := bytes.TrimSpace(s.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		v, err := parseValue(string(text))
+		if err != nil {
+			return nil, fmt.Errorf(`fuzzcorpus: line %d: %w`, line, err)
+		}
+		values = append(values, v)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// ReadFile reads a corpus file.
+func ReadFile(name string) (Entry, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return Entry{}, err
+	}
+	values, err := Parse(bytes.NewReader(b))
+	if err != nil {
+		return Entry{}, fmt.Errorf(`%s: %w`, name, err)
+	}
+	return Entry{Name: filepath.Base(name), Values: values}, nil
+}
+
+// ReadDir reads every corpus file in a directory, e.g.
+// testdata/fuzz/FuzzTimestampToDate, sorted by name. If name is a file, it
+// is read as a single entry.
+func ReadDir(name string) ([]Entry, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		entry, err := ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		return []Entry{entry}, nil
+	}
+	files, err := os.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		entry, err := ReadFile(filepath.Join(name, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return entries, nil
+}
+
+// Marshal encodes values in the corpus file format. Panics if any value
+// has an unsupported type.
+func Marshal(values ...any) []byte {
+	b := []byte(Header + "\n")
+	for _, v := range values {
+		switch v := v.(type) {
+		case []byte:
+			b = append(b, `[]byte(`...)
+			b = strconv.AppendQuote(b, string(v))
+			b = append(b, ')')
+		case string:
+			b = append(b, `string(`...)
+			b = strconv.AppendQuote(b, v)
+			b = append(b, ')')
+		case rune:
+			if utf8.ValidRune(v) {
+				b = fmt.Appendf(b, `rune(%s)`, strconv.QuoteRune(v))
+			} else {
+				b = fmt.Appendf(b, `int32(%d)`, v)
+			}
+		case byte:
+			b = fmt.Appendf(b, `byte(%s)`, strconv.QuoteRune(rune(v)))
+		case float32:
+			if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+				b = fmt.Appendf(b, `math.Float32frombits(0x%x)`, math.Float32bits(v))
+			} else {
+				b = fmt.Appendf(b, `float32(%v)`, v)
+			}
+		case float64:
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				b = fmt.Appendf(b, `math.Float64frombits(0x%x)`, math.Float64bits(v))
+			} else {
+				b = fmt.Appendf(b, `float64(%v)`, v)
+			}
+		case bool, int, int8, int16, int64, uint, uint16, uint32, uint64:
+			b = fmt.Appendf(b, `%T(%v)`, v, v)
+		default:
+			panic(fmt.Errorf(`fuzzcorpus: unsupported type %T`, v))
+		}
+		b = append(b, '\n')
+	}
+	return b
+}
+
+func parseValue(s string) (any, error) {
+	expr, err := parser.ParseExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, fmt.Errorf(`expected a conversion: %s`, s)
+	}
+
+	var typ string
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		typ = fun.Name
+	case *ast.ArrayType:
+		if elt, ok := fun.Elt.(*ast.Ident); ok && fun.Len == nil && elt.Name == `byte` {
+			typ = `[]byte`
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := fun.X.(*ast.Ident); ok && pkg.Name == `math` {
+			typ = `math.` + fun.Sel.Name
+		}
+	}
+
+	arg := call.Args[0]
+	if typ == `bool` {
+		if ident, ok := arg.(*ast.Ident); ok {
+			switch ident.Name {
+			case `true`:
+				return true, nil
+			case `false`:
+				return false, nil
+			}
+		}
+		return nil, fmt.Errorf(`invalid bool: %s`, s)
+	}
+
+	var neg bool
+	if unary, ok := arg.(*ast.UnaryExpr); ok && unary.Op == token.SUB {
+		neg, arg = true, unary.X
+	}
+	lit, ok := arg.(*ast.BasicLit)
+	if !ok {
+		return nil, fmt.Errorf(`expected a literal: %s`, s)
+	}
+	text := lit.Value
+	if neg {
+		text = `-` + text
+	}
+
+	switch typ {
+	case `[]byte`, `string`:
+		if lit.Kind != token.STRING || neg {
+			break
+		}
+		v, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return nil, err
+		}
+		if typ == `string` {
+			return v, nil
+		}
+		return []byte(v), nil
+	case `rune`, `int32`, `byte`, `uint8`:
+		if lit.Kind == token.CHAR && !neg {
+			v, _, _, err := strconv.UnquoteChar(lit.Value[1:len(lit.Value)-1], '\'')
+			if err != nil {
+				return nil, err
+			}
+			if typ == `rune` || typ == `int32` {
+				return v, nil
+			}
+			if v > math.MaxUint8 {
+				return nil, fmt.Errorf(`byte out of range: %s`, s)
+			}
+			return byte(v), nil
+		}
+		if typ == `rune` || typ == `int32` {
+			v, err := strconv.ParseInt(text, 0, 32)
+			return int32(v), err
+		}
+		v, err := strconv.ParseUint(text, 0, 8)
+		return uint8(v), err
+	case `int`:
+		v, err := strconv.ParseInt(text, 0, strconv.IntSize)
+		return int(v), err
+	case `int8`:
+		v, err := strconv.ParseInt(text, 0, 8)
+		return int8(v), err
+	case `int16`:
+		v, err := strconv.ParseInt(text, 0, 16)
+		return int16(v), err
+	case `int64`:
+		return strconv.ParseInt(text, 0, 64)
+	case `uint`:
+		v, err := strconv.ParseUint(text, 0, strconv.IntSize)
+		return uint(v), err
+	case `uint16`:
+		v, err := strconv.ParseUint(text, 0, 16)
+		return uint16(v), err
+	case `uint32`:
+		v, err := strconv.ParseUint(text, 0, 32)
+		return uint32(v), err
+	case `uint64`:
+		return strconv.ParseUint(text, 0, 64)
+	case `float32`:
+		v, err := strconv.ParseFloat(text, 32)
+		return float32(v), err
+	case `float64`:
+		return strconv.ParseFloat(text, 64)
+	case `math.Float32frombits`:
+		v, err := strconv.ParseUint(text, 0, 32)
+		return math.Float32frombits(uint32(v)), err
+	case `math.Float64frombits`:
+		v, err := strconv.ParseUint(text, 0, 64)
+		return math.Float64frombits(v), err
+	}
+	return nil, fmt.Errorf(`unsupported value: %s`, s)
+}
@@ -0,0 +1,94 @@
+package fuzzcorpus
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshal_roundTrip(t *testing.T) {
+	values := []any{
+		[]byte("a\x00b"),
+		"some\tstring\n",
+		'x',
+		rune(-1),
+		byte('\n'),
+		float32(1.5),
+		math.Inf(-1),
+		true,
+		false,
+		math.MinInt,
+		int8(-128),
+		int16(300),
+		int64(1706745599000000078),
+		uint(7),
+		uint16(65535),
+		uint32(1 << 31),
+		uint64(math.MaxUint64),
+	}
+	b := Marshal(values...)
+	actual, err := Parse(strings.NewReader(string(b)))
+	if err != nil {
+		t.Fatalf("%v\n%s", err, b)
+	}
+	if !reflect.DeepEqual(actual, values) {
+		t.Errorf("unexpected values:\n%#v\n%s", actual, b)
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, values := range map[string][]any{
+		`b`: {int(-32400), bool(true)},
+		`a`: {int64(1)},
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), Marshal(values...), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, `c`), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []Entry{{`a`, []any{int64(1)}}, {`b`, []any{int(-32400), true}}}; !reflect.DeepEqual(entries, expected) {
+		t.Errorf("unexpected entries: %#v", entries)
+	}
+	if entries, err := ReadDir(filepath.Join(dir, `b`)); err != nil || len(entries) != 1 || entries[0].Name != `b` {
+		t.Errorf("unexpected entries: %#v, %v", entries, err)
+	}
+}
+
+func TestParse_goTest(t *testing.T) {
+	// as written by go test
+	values, err := Parse(strings.NewReader("go test fuzz v1\nint64(1704067200000000000)\nint(50)\nint64(1706745599000000078)\nint(-32400)\nint64(1704067200000000000)\nbool(false)\nbool(false)\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []any{int64(1704067200000000000), 50, int64(1706745599000000078), -32400, int64(1704067200000000000), false, false}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("unexpected values: %#v", values)
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	for _, s := range [...]string{
+		``,
+		"go test fuzz v2\nint(1)\n",
+		"go test fuzz v1\nint(1\n",
+		"go test fuzz v1\nint(1, 2)\n",
+		"go test fuzz v1\nint8(128)\n",
+		"go test fuzz v1\nbool(1)\n",
+		"go test fuzz v1\nstring(-1)\n",
+		"go test fuzz v1\ncomplex128(1)\n",
+		"go test fuzz v1\nx\n",
+	} {
+		if values, err := Parse(strings.NewReader(s)); err == nil {
+			t.Errorf("expected error for %q, got %#v", s, values)
+		}
+	}
+}
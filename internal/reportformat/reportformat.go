@@ -0,0 +1,34 @@
+package reportformat
+
+import (
+	"fmt"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"io"
+)
+
+// Usage describes the supported formats, for use as flag usage.
+const Usage = `report format, one of "text" (summary table), "jsonl" (JSON lines), or "junit" (JUnit XML)`
+
+// Validate returns an error if format is not supported.
+func Validate(format string) error {
+	switch format {
+	case `text`, `jsonl`, `junit`:
+		return nil
+	default:
+		return fmt.Errorf(`unknown report format: %q`, format)
+	}
+}
+
+// Write renders the reports, in the given format.
+func Write(w io.Writer, format string, reports ...*baseline.Report) error {
+	switch format {
+	case `text`:
+		return baseline.WriteReportSummary(w, reports...)
+	case `jsonl`:
+		return baseline.WriteReportJSONLines(w, reports...)
+	case `junit`:
+		return baseline.WriteReportJUnit(w, reports...)
+	default:
+		return Validate(format)
+	}
+}
@@ -4,18 +4,19 @@ import (
 	"bytes"
 	"errors"
 	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"strconv"
 	"time"
 )
 
 func AppendInput(b []byte, input [2]time.Time) ([]byte, error) {
 	if input[0] != (time.Time{}) {
-		b = input[0].AppendFormat(b, time.RFC3339Nano)
+		b = baseline.AppendTimestamp(b, input[0])
 	}
 
 	b = append(b, '\t')
 
 	if input[1] != (time.Time{}) {
-		b = input[1].AppendFormat(b, time.RFC3339Nano)
+		b = baseline.AppendTimestamp(b, input[1])
 	}
 
 	b = append(b, '\n')
@@ -31,6 +32,29 @@ func ParseOutput(b []byte) (output [2]string, _ error) {
 	return [2]string{string(b[:i]), string(b[i+1:])}, nil
 }
 
+// AppendInputID is a variant of [AppendInput], prefixing the input with a
+// tab-separated id, allowing pipelined commands to respond out of order.
+func AppendInputID(b []byte, id uint64, input [2]time.Time) ([]byte, error) {
+	b = strconv.AppendUint(b, id, 10)
+	b = append(b, '\t')
+	return AppendInput(b, input)
+}
+
+// ParseOutputID is a variant of [ParseOutput], for output prefixed with a
+// tab-separated id, see [AppendInputID].
+func ParseOutputID(b []byte) (id uint64, output [2]string, err error) {
+	i := bytes.IndexRune(b, '\t')
+	if i == -1 {
+		return id, output, errors.New("unexpected output format")
+	}
+	id, err = strconv.ParseUint(string(b[:i]), 10, 64)
+	if err != nil {
+		return id, output, err
+	}
+	output, err = ParseOutput(b[i+1:])
+	return id, output, err
+}
+
 func CallToConvert(call func(input [2]time.Time) ([2]string, error)) baseline.TimestampToDate {
 	return func(startTime, endTime time.Time) (startDate, endDate string) {
 		v, err := call([2]time.Time{startTime, endTime})
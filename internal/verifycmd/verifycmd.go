@@ -0,0 +1,179 @@
+// Package verifycmd implements the flags and reporting common to the
+// commands that verify an external command, e.g.
+// cmd/verify-timestamp-to-date.
+//
+// The -format flag controls how the results are reported, on stdout, and
+// the exit code is non-zero if any case failed. The -parallel flag sets the
+// number of instances of the command to run, with cases distributed between
+// them, though the results are reported in the same order regardless. The
+// -window flag sets the number of cases that may be in flight at once, per
+// instance, i.e. pipelining, with the outputs expected in the same order as
+// the inputs, unless the -ids flag is set, in which case each input line is
+// prefixed with a tab-separated id, which must prefix the corresponding
+// output line, allowing outputs in any order. The -restarts flag sets the
+// number of times that failed instances may be restarted, in total, with
+// backoff, 0 failing the run on the first failure, and the -retries flag the
+// number of times that each case in flight on a failed instance may be
+// retried, see [extcmd.Config]. The -timeout flag sets the
+// maximum time to wait for the output of each case, after which the
+// instance is considered to have failed, e.g. because it buffers its
+// output, see [extcmd.TimeoutError]. Zero disables it. The stderr of the
+// command is attached to the failed cases it is attributed to, see
+// [extcmd.Future.Stderr], and is only written to stderr (prefixed) if the
+// -stderr flag is set. Once all cases are complete, the stdin of each
+// instance is closed, and it is interrupted if it hasn't exited within the
+// -grace-period. The -check-exit flag fails the run if any instance then
+// exits with an error, e.g. a non-zero exit code, and the
+// -check-unread-output flag if any instance writes output that doesn't
+// correspond to a case, e.g. at EOF, see [extcmd.Config.CheckExit].
+package verifycmd
+
+import (
+	"flag"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/extcmd"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/reportformat"
+	"os"
+	"sync"
+	"time"
+)
+
+// Usage is the synopsis of the common flags.
+const Usage = `[-format text|jsonl|junit] [-parallel n] [-window n] [-ids] [-restarts n] [-retries n] [-timeout d] [-stderr] [-grace-period d] [-check-exit] [-check-unread-output]`
+
+type (
+	// Flags are the values of the common flags, see [RegisterFlags].
+	Flags struct {
+		Format            string
+		Parallel          int
+		Window            int
+		IDs               bool
+		Restarts          int
+		Retries           int
+		Timeout           time.Duration
+		Stderr            bool
+		GracePeriod       time.Duration
+		CheckExit         bool
+		CheckUnreadOutput bool
+	}
+
+	// Calls records the calls made on behalf of each case of a report, by
+	// index, so the stderr attributed to them may be attached to the failed
+	// cases, see [Calls.Attach]. The zero value is ready to use.
+	Calls[Input any, Output any] struct {
+		mu      sync.Mutex
+		futures map[int][]*extcmd.Future[Output]
+	}
+)
+
+// RegisterFlags defines the common flags, on [flag.CommandLine].
+func RegisterFlags() *Flags {
+	var x Flags
+	flag.StringVar(&x.Format, `format`, `text`, reportformat.Usage)
+	flag.IntVar(&x.Parallel, `parallel`, 1, `number of instances of the command to run concurrently`)
+	flag.IntVar(&x.Window, `window`, 1, `number of cases in flight at once (pipelining)`)
+	flag.BoolVar(&x.IDs, `ids`, false, `prefix each line with an id, allowing outputs in any order`)
+	flag.IntVar(&x.Restarts, `restarts`, 3, `number of times failed instances may be restarted, in total (0 to disable)`)
+	flag.IntVar(&x.Retries, `retries`, 0, `number of times a case may be retried, after its instance fails`)
+	flag.DurationVar(&x.Timeout, `timeout`, 10*time.Second, `maximum time to wait for the output of each case (0 to disable)`)
+	flag.BoolVar(&x.Stderr, `stderr`, false, `write the stderr of the command to stderr, prefixed`)
+	flag.DurationVar(&x.GracePeriod, `grace-period`, time.Second, `time to wait for instances to exit, after closing stdin, before interrupting them`)
+	flag.BoolVar(&x.CheckExit, `check-exit`, false, `fail if any instance exits with an error, after its stdin is closed`)
+	flag.BoolVar(&x.CheckUnreadOutput, `check-unread-output`, false, `fail if any instance writes output that doesn't correspond to a case`)
+	return &x
+}
+
+// Valid returns false if any of the flags are out of range.
+func (x *Flags) Valid() bool {
+	return reportformat.Validate(x.Format) == nil && x.Parallel >= 1 && x.Window >= 1 &&
+		x.Restarts >= 0 && x.Retries >= 0 && x.Timeout >= 0 && x.GracePeriod > 0
+}
+
+// Concurrency returns the number of cases to run concurrently, i.e. enough
+// to fill the window of every instance.
+func (x *Flags) Concurrency() int {
+	return x.Parallel * x.Window
+}
+
+// NewConfig returns the [extcmd.Config] for the command, per flags. The
+// caller must set the input and output functions, including the id
+// variants, if [Flags.IDs] is set.
+func NewConfig[Input any, Output any](flags *Flags, command string, args []string) extcmd.Config[Input, Output] {
+	config := extcmd.Config[Input, Output]{
+		Command:           command,
+		Args:              args,
+		Window:            flags.Window,
+		Workers:           flags.Parallel,
+		MaxRestarts:       flags.Restarts,
+		Retries:           flags.Retries,
+		CallTimeout:       flags.Timeout,
+		GracePeriod:       flags.GracePeriod,
+		CheckExit:         flags.CheckExit,
+		CheckUnreadOutput: flags.CheckUnreadOutput,
+	}
+	if flags.Restarts == 0 {
+		// N.B. zero would use the default of extcmd
+		config.MaxRestarts = -1
+	}
+	if flags.Stderr {
+		config.Stderr, config.StderrPrefix = os.Stderr, `stderr: `
+	}
+	return config
+}
+
+// Exit writes the reports to stdout, per [Flags.Format], then exits, with
+// a non-zero code if err is non-nil, or any case failed. The reports are
+// written regardless of err, e.g. if -check-exit failed, unless there are
+// none.
+func (x *Flags) Exit(reports []*baseline.Report, err error) {
+	if len(reports) != 0 || err == nil {
+		if writeErr := reportformat.Write(os.Stdout, x.Format, reports...); err == nil {
+			err = writeErr
+		}
+	}
+	if err != nil {
+		_, _ = os.Stderr.WriteString(`ERROR: ` + err.Error())
+		os.Exit(1)
+	}
+	for _, report := range reports {
+		if report.Failure() != nil {
+			os.Exit(1)
+		}
+	}
+	os.Exit(0)
+}
+
+// Call returns a function that calls the command, using client, on behalf
+// of the case at index i.
+func (x *Calls[Input, Output]) Call(client *extcmd.Client[Input, Output], i int) func(input Input) (Output, error) {
+	return func(input Input) (Output, error) {
+		future := client.Go(input)
+		x.mu.Lock()
+		if x.futures == nil {
+			x.futures = make(map[int][]*extcmd.Future[Output])
+		}
+		x.futures[i] = append(x.futures[i], future)
+		x.mu.Unlock()
+		return future.Result()
+	}
+}
+
+// Attach populates [baseline.CaseResult.Stderr] for each failed case of
+// report, from the error, if it has any, or else from the calls made on
+// behalf of the case. It must be called after the command has exited, so
+// all stderr has been read.
+func (x *Calls[Input, Output]) Attach(report *baseline.Report) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for i, v := range report.Cases {
+		if v.Err == nil {
+			continue
+		}
+		if v.Stderr = extcmd.Stderr(v.Err); v.Stderr == nil {
+			for _, future := range x.futures[i] {
+				v.Stderr = append(v.Stderr, future.Stderr()...)
+			}
+		}
+		report.Cases[i] = v
+	}
+}
@@ -0,0 +1,26 @@
+package widen
+
+import (
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/baseline"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/datetotimestamp"
+	"github.com/joeycumines/dates-timestamps-and-aggregated-data/internal/timestamptodate"
+	"time"
+)
+
+func AppendInput(b []byte, input [2]time.Time) ([]byte, error) {
+	return timestamptodate.AppendInput(b, input)
+}
+
+func ParseOutput(b []byte) ([2]time.Time, error) {
+	return datetotimestamp.ParseOutput(b)
+}
+
+func CallToWiden(call func(input [2]time.Time) ([2]time.Time, error)) baseline.Widen {
+	return func(startTime, endTime time.Time) (wideStartTime, wideEndTime time.Time) {
+		v, err := call([2]time.Time{startTime, endTime})
+		if err != nil {
+			panic(err)
+		}
+		return v[0], v[1]
+	}
+}